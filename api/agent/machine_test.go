@@ -18,6 +18,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	apiserveragent "github.com/juju/juju/apiserver/agent"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/mongotest"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher/watchertest"
 )
 
 func TestAll(t *stdtesting.T) {
@@ -97,6 +99,46 @@ func (s *servingInfoSuite) TestIsMasterPermission(c *gc.C) {
 	})
 }
 
+func (s *servingInfoSuite) TestModelCredential(c *gc.C) {
+	st, _ := s.OpenAPIAsNewMachine(c, state.JobManageModel)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	expected, ok := model.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+
+	tag, ok, err := apiagent.NewState(st).ModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(tag, gc.Equals, expected)
+}
+
+func (s *servingInfoSuite) TestWatchCredential(c *gc.C) {
+	st, _ := s.OpenAPIAsNewMachine(c, state.JobManageModel)
+
+	tag := names.NewCloudCredentialTag("dummy/fred/default")
+	w, err := apiagent.NewState(st).WatchCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// The initial event is consumed by the server.
+	wc.AssertNoChange()
+
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.UserPassAuthType, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *servingInfoSuite) TestWatchCredentialPermission(c *gc.C) {
+	st, _ := s.OpenAPIAsNewMachine(c)
+	_, err := apiagent.NewState(st).WatchCredential(names.NewCloudCredentialTag("dummy/fred/default"))
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "permission denied",
+		Code:    "unauthorized access",
+	})
+}
+
 type machineSuite struct {
 	testing.JujuConnSuite
 	machine *state.Machine
@@ -75,14 +75,42 @@ func (st *State) IsMaster() (bool, error) {
 	return results.Master, err
 }
 
+// ModelCredential returns the tag of the cloud credential used by the
+// model, and a boolean indicating whether the model has one.
+func (c *State) ModelCredential() (names.CloudCredentialTag, bool, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return names.CloudCredentialTag{}, false, errors.NotSupportedf("ModelCredential")
+	}
+	var result params.ModelCredential
+	err := c.facade.FacadeCall("ModelCredential", nil, &result)
+	if err != nil {
+		return names.CloudCredentialTag{}, false, errors.Trace(err)
+	}
+	if result.CloudCredential == "" {
+		return names.CloudCredentialTag{}, false, nil
+	}
+	tag, err := names.ParseCloudCredentialTag(result.CloudCredential)
+	if err != nil {
+		return names.CloudCredentialTag{}, false, errors.Trace(err)
+	}
+	return tag, true, nil
+}
+
 // WatchCredential returns a watcher which reports when the specified
 // credential has changed.
 func (c *State) WatchCredential(tag names.CloudCredentialTag) (watcher.NotifyWatcher, error) {
-	var result params.NotifyWatchResult
-	err := c.facade.FacadeCall("WatchCredential", nil, &result)
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := c.facade.FacadeCall("WatchCredentials", args, &results)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -103,19 +104,7 @@ type DeployArgs struct {
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
 	deployArgs := params.ApplicationsDeploy{
-		Applications: []params.ApplicationDeploy{{
-			ApplicationName:  args.ApplicationName,
-			Series:           args.Series,
-			CharmURL:         args.CharmID.URL.String(),
-			Channel:          string(args.CharmID.Channel),
-			NumUnits:         args.NumUnits,
-			ConfigYAML:       args.ConfigYAML,
-			Constraints:      args.Cons,
-			Placement:        args.Placement,
-			Storage:          args.Storage,
-			EndpointBindings: args.EndpointBindings,
-			Resources:        args.Resources,
-		}},
+		Applications: []params.ApplicationDeploy{deployParams(args)},
 	}
 	var results params.ErrorResults
 	var err error
@@ -126,6 +115,41 @@ func (c *Client) Deploy(args DeployArgs) error {
 	return errors.Trace(results.OneError())
 }
 
+// DeployApplications deploys each of the given applications in a
+// single call, returning the result of deploying each.
+func (c *Client) DeployApplications(args ...DeployArgs) ([]params.ErrorResult, error) {
+	deployArgs := params.ApplicationsDeploy{
+		Applications: make([]params.ApplicationDeploy, len(args)),
+	}
+	for i, arg := range args {
+		deployArgs.Applications[i] = deployParams(arg)
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Deploy", deployArgs, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args), n)
+	}
+	return results.Results, nil
+}
+
+func deployParams(args DeployArgs) params.ApplicationDeploy {
+	return params.ApplicationDeploy{
+		ApplicationName:  args.ApplicationName,
+		Series:           args.Series,
+		CharmURL:         args.CharmID.URL.String(),
+		Channel:          string(args.CharmID.Channel),
+		NumUnits:         args.NumUnits,
+		ConfigYAML:       args.ConfigYAML,
+		Constraints:      args.Cons,
+		Placement:        args.Placement,
+		Storage:          args.Storage,
+		EndpointBindings: args.EndpointBindings,
+		Resources:        args.Resources,
+	}
+}
+
 // GetCharmURL returns the charm URL the given service is
 // running at present.
 func (c *Client) GetCharmURL(serviceName string) (*charm.URL, error) {
@@ -232,6 +256,23 @@ func (c *Client) AddUnits(application string, numUnits int, placement []*instanc
 	return results.Units, err
 }
 
+// AddApplicationUnits adds units to each of the given applications in a
+// single call, returning the units added to each.
+func (c *Client) AddApplicationUnits(args ...params.AddApplicationUnits) ([]params.AddApplicationUnitsResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("adding units to many applications in one call")
+	}
+	var results params.AddApplicationUnitsResultList
+	err := c.facade.FacadeCall("AddApplicationUnits", params.AddApplicationUnitsArgs{Args: args}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args), n)
+	}
+	return results.Results, nil
+}
+
 // DestroyUnitsDeprecated decreases the number of units dedicated to an
 // application.
 //
@@ -352,6 +393,64 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeEndpoints changes the juju-managed firewall to expose only the
+// given port ranges of the named endpoints of an application.
+func (c *Client) ExposeEndpoints(application string, endpoints map[string][]network.PortRange) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("exposing individual endpoints")
+	}
+	args := params.ApplicationExposeEndpoints{
+		ApplicationName: application,
+		Endpoints:       make(map[string][]string, len(endpoints)),
+	}
+	for name, portRanges := range endpoints {
+		specs := make([]string, len(portRanges))
+		for i, portRange := range portRanges {
+			specs[i] = portRange.String()
+		}
+		args.Endpoints[name] = specs
+	}
+	return c.facade.FacadeCall("ExposeEndpoints", args, nil)
+}
+
+// ExposeApplications exposes each of the given applications in a
+// single call, returning the result of exposing each.
+func (c *Client) ExposeApplications(appNames ...string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("exposing many applications in one call")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(appNames)),
+	}
+	allResults := make([]params.ErrorResult, len(appNames))
+	index := make([]int, 0, len(appNames))
+	for i, name := range appNames {
+		if !names.IsValidApplication(name) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("application name %q", name).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewApplicationTag(name).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.ErrorResults
+		if err := c.facade.FacadeCall("ExposeApplications", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -376,6 +475,23 @@ func (c *Client) Set(application string, options map[string]string) error {
 	return c.facade.FacadeCall("Set", p, nil)
 }
 
+// SetConfig sets configuration options on each of the given
+// applications in a single call, returning the result of setting each.
+func (c *Client) SetConfig(args ...params.ApplicationSet) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("configuring many applications in one call")
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("SetApplicationsConfig", params.ApplicationSetArgs{Args: args}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args), n)
+	}
+	return results.Results, nil
+}
+
 // Unset resets configuration options on an application.
 func (c *Client) Unset(application string, options []string) error {
 	p := params.ApplicationUnset{
@@ -401,6 +517,28 @@ func (c *Client) AddRelation(endpoints ...string) (*params.AddRelationResults, e
 	return &addRelRes, err
 }
 
+// AddRelations adds each of the given relations, each specified by its
+// endpoints, in a single call, returning the relation info for each.
+func (c *Client) AddRelations(relations ...[]string) ([]params.AddRelationResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("adding many relations in one call")
+	}
+	args := params.AddRelations{
+		Relations: make([]params.AddRelation, len(relations)),
+	}
+	for i, endpoints := range relations {
+		args.Relations[i].Endpoints = endpoints
+	}
+	var results params.AddRelationsResults
+	if err := c.facade.FacadeCall("AddRelations", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(relations) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(relations), n)
+	}
+	return results.Results, nil
+}
+
 // DestroyRelation removes the relation between the specified endpoints.
 func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
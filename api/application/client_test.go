@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -200,6 +202,56 @@ func (s *applicationSuite) TestConsume(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+// versionedAPICaller is an APICallerFunc reporting a fixed best
+// facade version.
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "ExposeEndpoints")
+			c.Assert(version, gc.Equals, 5)
+			c.Assert(a, jc.DeepEquals, params.ApplicationExposeEndpoints{
+				ApplicationName: "foo",
+				Endpoints:       map[string][]string{"website": {"80/tcp", "8000-8080/udp"}},
+			})
+			return nil
+		},
+		version: 5,
+	})
+	err := client.ExposeEndpoints("foo", map[string][]network.PortRange{
+		"website": {
+			network.MustParsePortRange("80/tcp"),
+			network.MustParsePortRange("8000-8080/udp"),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 4,
+	})
+	err := client.ExposeEndpoints("foo", map[string][]network.PortRange{
+		"website": {network.MustParsePortRange("80/tcp")},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -332,3 +384,125 @@ func (s *applicationSuite) TestDestroyUnitsInvalidIds(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *applicationSuite) TestAddApplicationUnits(c *gc.C) {
+	expectedResults := []params.AddApplicationUnitsResult{{
+		Units: []string{"foo/1", "foo/2"},
+	}, {
+		Error: &params.Error{Message: "boo"},
+	}}
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "AddApplicationUnits")
+			c.Assert(a, jc.DeepEquals, params.AddApplicationUnitsArgs{
+				Args: []params.AddApplicationUnits{
+					{ApplicationName: "foo", NumUnits: 2},
+					{ApplicationName: "bar", NumUnits: 1},
+				},
+			})
+			out := response.(*params.AddApplicationUnitsResultList)
+			*out = params.AddApplicationUnitsResultList{expectedResults}
+			return nil
+		},
+		version: 6,
+	})
+	results, err := client.AddApplicationUnits(
+		params.AddApplicationUnits{ApplicationName: "foo", NumUnits: 2},
+		params.AddApplicationUnits{ApplicationName: "bar", NumUnits: 1},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestSetConfig(c *gc.C) {
+	expectedResults := []params.ErrorResult{{}, {Error: &params.Error{Message: "boo"}}}
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetApplicationsConfig")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetArgs{
+				Args: []params.ApplicationSet{
+					{ApplicationName: "foo", Options: map[string]string{"a": "1"}},
+					{ApplicationName: "bar", Options: map[string]string{"b": "2"}},
+				},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{expectedResults}
+			return nil
+		},
+		version: 6,
+	})
+	results, err := client.SetConfig(
+		params.ApplicationSet{ApplicationName: "foo", Options: map[string]string{"a": "1"}},
+		params.ApplicationSet{ApplicationName: "bar", Options: map[string]string{"b": "2"}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestExposeApplications(c *gc.C) {
+	expectedResults := []params.ErrorResult{{
+		Error: &params.Error{Message: `application name "!" not valid`},
+	}, {}}
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "ExposeApplications")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{expectedResults[1:]}
+			return nil
+		},
+		version: 6,
+	})
+	results, err := client.ExposeApplications("!", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestAddRelations(c *gc.C) {
+	expectedResults := []params.AddRelationResult{{
+		Endpoints: map[string]params.CharmRelation{"wordpress": {Name: "db"}},
+	}, {
+		Error: &params.Error{Message: "boo"},
+	}}
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "AddRelations")
+			c.Assert(a, jc.DeepEquals, params.AddRelations{
+				Relations: []params.AddRelation{
+					{Endpoints: []string{"wordpress", "mysql"}},
+					{Endpoints: []string{"wordpress", "memcached"}},
+				},
+			})
+			out := response.(*params.AddRelationsResults)
+			*out = params.AddRelationsResults{expectedResults}
+			return nil
+		},
+		version: 6,
+	})
+	results, err := client.AddRelations(
+		[]string{"wordpress", "mysql"},
+		[]string{"wordpress", "memcached"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestBulkCallsNotSupported(c *gc.C) {
+	client := application.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 5,
+	})
+	_, err := client.AddApplicationUnits(params.AddApplicationUnits{ApplicationName: "foo", NumUnits: 1})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.SetConfig(params.ApplicationSet{ApplicationName: "foo"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ExposeApplications("foo")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.AddRelations([]string{"wordpress", "mysql"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
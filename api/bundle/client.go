@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to interact
+// with bundles on the Juju server.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Bundle")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportBundle returns the current model as a bundle, in YAML form.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("exporting bundles on this controller")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
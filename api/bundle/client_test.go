@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type bundleSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&bundleSuite{})
+
+// bundleV2APICaller is an APICallerFunc that reports version 2 of the
+// Bundle facade as the best version available.
+type bundleV2APICaller struct {
+	basetesting.APICallerFunc
+}
+
+func (bundleV2APICaller) BestFacadeVersion(facade string) int {
+	return 2
+}
+
+func (s *bundleSuite) TestExportBundle(c *gc.C) {
+	apiCaller := bundleV2APICaller{basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.StringResult{})
+			*(result.(*params.StringResult)) = params.StringResult{
+				Result: "applications: {}\n",
+			}
+			return nil
+		},
+	)}
+	client := bundle.NewClient(apiCaller)
+	result, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "applications: {}\n")
+}
+
+func (s *bundleSuite) TestExportBundleError(c *gc.C) {
+	apiCaller := bundleV2APICaller{basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.StringResult)) = params.StringResult{
+				Error: &params.Error{Message: "permission denied"},
+			}
+			return nil
+		},
+	)}
+	client := bundle.NewClient(apiCaller)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *bundleSuite) TestExportBundleNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	)
+	client := bundle.NewClient(apiCaller)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "exporting bundles on this controller not supported")
+}
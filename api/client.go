@@ -4,6 +4,8 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -316,11 +318,21 @@ func (c *Client) AddLocalCharm(curl *charm.URL, ch charm.Charm) (*charm.URL, err
 }
 
 // UploadCharm sends the content to the API server using an HTTP post.
+// The SHA-256 hash of the content is sent along with it, so that the
+// API server can verify that the archive arrived intact.
 func (c *Client) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return nil, errors.Annotate(err, "cannot hash charm archive")
+	}
+	if _, err := content.Seek(0, 0); err != nil {
+		return nil, errors.Annotate(err, "cannot rewind charm archive")
+	}
 	args := url.Values{}
 	args.Add("series", curl.Series)
 	args.Add("schema", curl.Schema)
 	args.Add("revision", strconv.Itoa(curl.Revision))
+	args.Add("sha256", hex.EncodeToString(hash.Sum(nil)))
 	apiURI := url.URL{Path: "/charms", RawQuery: args.Encode()}
 
 	contentType := "application/zip"
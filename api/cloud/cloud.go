@@ -84,6 +84,42 @@ func cloudFromParams(cloudName string, p params.Cloud) jujucloud.Cloud {
 	}
 }
 
+// AddCloud adds a new cloud definition to the controller.
+func (c *Client) AddCloud(cloud jujucloud.Cloud) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("adding clouds on this controller")
+	}
+	args := params.AddCloudArgs{
+		Name:  cloud.Name,
+		Cloud: cloudToParams(cloud),
+	}
+	return errors.Trace(c.facade.FacadeCall("AddCloud", args, nil))
+}
+
+func cloudToParams(cloud jujucloud.Cloud) params.Cloud {
+	authTypes := make([]string, len(cloud.AuthTypes))
+	for i, authType := range cloud.AuthTypes {
+		authTypes[i] = string(authType)
+	}
+	regions := make([]params.CloudRegion, len(cloud.Regions))
+	for i, region := range cloud.Regions {
+		regions[i] = params.CloudRegion{
+			Name:             region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+		}
+	}
+	return params.Cloud{
+		Type:             cloud.Type,
+		AuthTypes:        authTypes,
+		Endpoint:         cloud.Endpoint,
+		IdentityEndpoint: cloud.IdentityEndpoint,
+		StorageEndpoint:  cloud.StorageEndpoint,
+		Regions:          regions,
+	}
+}
+
 // DefaultCloud returns the tag of the cloud that models will be
 // created in by default.
 func (c *Client) DefaultCloud() (names.CloudTag, error) {
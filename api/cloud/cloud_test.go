@@ -286,3 +286,69 @@ func (s *cloudSuite) TestCredentials(c *gc.C) {
 		},
 	})
 }
+
+// cloudV2APICaller is an APICallerFunc that reports version 2 of the
+// Cloud facade as the best version available.
+type cloudV2APICaller struct {
+	basetesting.APICallerFunc
+}
+
+func (cloudV2APICaller) BestFacadeVersion(facade string) int {
+	return 2
+}
+
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	var called bool
+	apiCaller := cloudV2APICaller{basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Cloud")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddCloud")
+			c.Check(a, jc.DeepEquals, params.AddCloudArgs{
+				Name: "foo",
+				Cloud: params.Cloud{
+					Type:      "openstack",
+					AuthTypes: []string{"userpass"},
+					Endpoint:  "https://keystone.example.com",
+					Regions:   []params.CloudRegion{{Name: "one"}},
+				},
+			})
+			c.Check(result, gc.IsNil)
+			return nil
+		},
+	)}
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.AddCloud(cloud.Cloud{
+		Name:      "foo",
+		Type:      "openstack",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+		Endpoint:  "https://keystone.example.com",
+		Regions:   []cloud.Region{{Name: "one"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestAddCloudNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call %q", request)
+			return nil
+		},
+	)
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.AddCloud(cloud.Cloud{Name: "foo", Type: "openstack"})
+	c.Assert(err, gc.ErrorMatches, "adding clouds on this controller not supported")
+}
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -194,6 +195,48 @@ func (c *Client) modifyControllerUser(action params.ControllerAction, user, acce
 	return result.Combine()
 }
 
+// AuditLogFilter selects the audit log entries returned by AuditLog.
+// Zero fields do not restrict the entries returned.
+type AuditLogFilter struct {
+	// User selects the entries for requests made by this user.
+	User string
+
+	// After selects the entries recorded at or after this time.
+	After time.Time
+
+	// Before selects the entries recorded before this time.
+	Before time.Time
+
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
+
+// AuditLog returns the entries of the controller's audit log that
+// match the filter, newest first.
+func (c *Client) AuditLog(filter AuditLogFilter) ([]params.AuditLogEntry, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("reading the audit log on this controller")
+	}
+	args := params.AuditLogArgs{Limit: filter.Limit}
+	if filter.User != "" {
+		if !names.IsValidUser(filter.User) {
+			return nil, errors.Errorf("invalid username: %q", filter.User)
+		}
+		args.UserTag = names.NewUserTag(filter.User).String()
+	}
+	if !filter.After.IsZero() {
+		args.After = &filter.After
+	}
+	if !filter.Before.IsZero() {
+		args.Before = &filter.Before
+	}
+	var result params.AuditLogResult
+	if err := c.facade.FacadeCall("AuditLog", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
 // GetControllerAccess returns the access level the user has on the controller.
 func (c *Client) GetControllerAccess(user string) (permission.Access, error) {
 	if !names.IsValidUser(user) {
@@ -6,7 +6,9 @@ package controller_test
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
+	coreerrors "github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -229,3 +231,71 @@ func makeSpec() controller.MigrationSpec {
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
+
+type versionedAPICaller struct {
+	apitesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *Suite) TestAuditLog(c *gc.C) {
+	after := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []params.AuditLogEntry{{
+		Timestamp:  after.Add(time.Minute),
+		OriginName: "user-bob",
+		Operation:  "Application:v4 - Deploy",
+	}}
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.AuditLogResult)) = params.AuditLogResult{Entries: entries}
+			return nil
+		},
+		version: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.AuditLog(controller.AuditLogFilter{
+		User:  "bob",
+		After: after,
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, entries)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.AuditLog", []interface{}{params.AuditLogArgs{
+			UserTag: "user-bob",
+			After:   &after,
+			Limit:   10,
+		}}},
+	})
+}
+
+func (s *Suite) TestAuditLogInvalidUser(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(controller.AuditLogFilter{User: "not/valid"})
+	c.Assert(err, gc.ErrorMatches, `invalid username: "not/valid"`)
+}
+
+func (s *Suite) TestAuditLogNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 3,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(controller.AuditLogFilter{})
+	c.Assert(err, jc.Satisfies, coreerrors.IsNotSupported)
+}
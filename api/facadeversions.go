@@ -12,29 +12,29 @@ package api
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
 	"Action":                       2,
-	"Agent":                        2,
+	"Agent":                        3,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  4,
+	"Application":                  6,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
-	"Cloud":                        1,
-	"Controller":                   3,
+	"Cloud":                        2,
+	"Controller":                   4,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
+	"Firewaller":                   4,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -60,7 +60,7 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  1,
-	"ModelManager":                 2,
+	"ModelManager":                 6,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
@@ -87,7 +87,7 @@ var facadeVersions = map[string]int{
 	"UnitAssigner":                 1,
 	"Uniter":                       4,
 	"Upgrader":                     1,
-	"UserManager":                  1,
+	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 }
 
@@ -6,10 +6,12 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +77,32 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedPortRanges returns the port ranges of the application's
+// exposed endpoints. If the result is empty, all of the ports opened
+// by an exposed application are reachable.
+func (s *Application) ExposedPortRanges() ([]network.PortRange, error) {
+	if s.st.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("exposing individual endpoints")
+	}
+	var results params.PortRangesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposedPortRanges", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var portRanges []network.PortRange
+	for _, portRange := range result.Result {
+		portRanges = append(portRanges, portRange.NetworkPortRange())
+	}
+	return portRanges, nil
+}
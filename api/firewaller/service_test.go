@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +92,20 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposedPortRanges(c *gc.C) {
+	portRanges, err := s.apiApplication.ExposedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, gc.HasLen, 0)
+
+	err = s.application.ExposeEndpoints(map[string][]network.PortRange{
+		"url": {{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	portRanges, err = s.apiApplication.ExposedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, jc.DeepEquals, []network.PortRange{
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+	})
+}
@@ -4,6 +4,9 @@
 package modelmanager_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -175,6 +178,172 @@ func (s *accessSuite) errorResult(c *gc.C, action params.ModelAction) {
 	c.Assert(err, gc.ErrorMatches, "unfortunate mishap")
 }
 
+func (s *accessSuite) TestGrantModelUsers(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, gc.HasLen, 2)
+			for i, user := range []string{"joe", "sam"} {
+				c.Assert(string(req.Changes[i].Action), gc.Equals, string(params.GrantModelAccess))
+				c.Assert(req.Changes[i].UserTag, gc.Equals, names.NewUserTag(user).String())
+				c.Assert(req.Changes[i].ModelTag, gc.Equals, someModelTag)
+			}
+
+			resp := assertResponse(c, result)
+			err := &params.Error{Message: "unfortunate mishap"}
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}, {Error: err}}}
+
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModelUsers([]string{"joe", "sam"}, "write", someModelUUID)
+	c.Assert(err, gc.ErrorMatches, `user "sam", model "`+someModelUUID+`": unfortunate mishap`)
+}
+
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *accessSuite) TestGrantModelUsersUntil(c *gc.C) {
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+			called = true
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, gc.HasLen, 1)
+			c.Assert(string(req.Changes[0].Action), gc.Equals, string(params.GrantModelAccess))
+			c.Assert(req.Changes[0].Expires, gc.NotNil)
+			c.Assert(req.Changes[0].Expires.Equal(expires), jc.IsTrue)
+
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+			return nil
+		},
+		version: 3,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModelUsersUntil([]string{"bob"}, "read", expires, someModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *accessSuite) TestGrantModelUsersUntilNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 2,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModelUsersUntil([]string{"bob"}, "read", time.Now(), someModelUUID)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *accessSuite) TestGrantGroup(c *gc.C) {
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+			called = true
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, jc.DeepEquals, []params.ModifyModelAccess{{
+				Group:    "devs",
+				Action:   params.GrantModelAccess,
+				Access:   params.ModelWriteAccess,
+				ModelTag: names.NewModelTag(someModelUUID).String(),
+			}})
+
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+			return nil
+		},
+		version: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModelUsers([]string{"@devs"}, "write", someModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *accessSuite) TestGrantGroupNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 3,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModelUsers([]string{"@devs"}, "write", someModelUUID)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *accessSuite) TestGrantApplications(c *gc.C) {
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+			called = true
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, jc.DeepEquals, []params.ModifyModelAccess{{
+				UserTag:      names.NewUserTag("bob").String(),
+				Action:       params.GrantModelAccess,
+				Access:       params.ModelWriteAccess,
+				ModelTag:     names.NewModelTag(someModelUUID).String(),
+				Applications: []string{"wordpress", "nginx"},
+			}})
+
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+			return nil
+		},
+		version: 6,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantApplications([]string{"bob"}, someModelUUID, "wordpress", "nginx")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *accessSuite) TestGrantApplicationsNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantApplications([]string{"bob"}, someModelUUID, "wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *accessSuite) TestRevokeApplicationsGroup(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 6,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.RevokeApplications([]string{"@devs"}, someModelUUID, "wordpress")
+	c.Assert(err, gc.ErrorMatches, "application access for groups not supported")
+}
+
 func (s *accessSuite) TestInvalidResultCount(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, result interface{}) error {
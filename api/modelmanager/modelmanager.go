@@ -4,6 +4,10 @@
 package modelmanager
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -239,13 +243,28 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 
 // DestroyModel puts the specified model into a "dying" state, which will
 // cause the model's resources to be cleaned up, after which the model will
-// be removed.
-func (c *Client) DestroyModel(tag names.ModelTag) error {
-	var results params.ErrorResults
-	entities := params.Entities{
-		Entities: []params.Entity{{Tag: tag.String()}},
+// be removed. If destroyStorage is false and the model contains
+// persistent storage, the model is not destroyed and an error satisfying
+// params.IsCodeHasPersistentStorage is returned.
+func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage bool) error {
+	var args interface{}
+	if c.BestAPIVersion() < 5 {
+		if !destroyStorage {
+			return errors.New("this controller always destroys a model's storage along with it; use --destroy-storage to destroy the model and its storage")
+		}
+		args = params.Entities{
+			Entities: []params.Entity{{Tag: tag.String()}},
+		}
+	} else {
+		args = params.DestroyModelsParams{
+			Models: []params.DestroyModelParams{{
+				ModelTag:       tag.String(),
+				DestroyStorage: destroyStorage,
+			}},
+		}
 	}
-	if err := c.facade.FacadeCall("DestroyModels", entities, &results); err != nil {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DestroyModels", args, &results); err != nil {
 		return errors.Trace(err)
 	}
 	if n := len(results.Results); n != 1 {
@@ -259,37 +278,91 @@ func (c *Client) DestroyModel(tag names.ModelTag) error {
 
 // GrantModel grants a user access to the specified models.
 func (c *Client) GrantModel(user, access string, modelUUIDs ...string) error {
-	return c.modifyModelUser(params.GrantModelAccess, user, access, modelUUIDs)
+	return c.modifyModelUsers(params.GrantModelAccess, []string{user}, access, nil, modelUUIDs)
 }
 
 // RevokeModel revokes a user's access to the specified models.
 func (c *Client) RevokeModel(user, access string, modelUUIDs ...string) error {
-	return c.modifyModelUser(params.RevokeModelAccess, user, access, modelUUIDs)
+	return c.modifyModelUsers(params.RevokeModelAccess, []string{user}, access, nil, modelUUIDs)
 }
 
-func (c *Client) modifyModelUser(action params.ModelAction, user, access string, modelUUIDs []string) error {
-	var args params.ModifyModelAccessRequest
+// GrantModelUsers grants each of the users access to the specified
+// models in a single call. A user name prefixed with "@" names a group
+// of users instead. Any failures are reported for each user and model
+// pair.
+func (c *Client) GrantModelUsers(users []string, access string, modelUUIDs ...string) error {
+	return c.modifyModelUsers(params.GrantModelAccess, users, access, nil, modelUUIDs)
+}
 
-	if !names.IsValidUser(user) {
-		return errors.Errorf("invalid username: %q", user)
+// RevokeModelUsers revokes each of the users' access to the specified
+// models in a single call. A user name prefixed with "@" names a group
+// of users instead. Any failures are reported for each user and model
+// pair.
+func (c *Client) RevokeModelUsers(users []string, access string, modelUUIDs ...string) error {
+	return c.modifyModelUsers(params.RevokeModelAccess, users, access, nil, modelUUIDs)
+}
+
+// GrantModelUsersUntil grants each of the users access to the specified
+// models until the given time, after which the controller revokes it.
+func (c *Client) GrantModelUsersUntil(users []string, access string, expires time.Time, modelUUIDs ...string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("expiring model access on this controller")
 	}
-	userTag := names.NewUserTag(user)
+	return c.modifyModelUsers(params.GrantModelAccess, users, access, &expires, modelUUIDs)
+}
 
+func (c *Client) modifyModelUsers(action params.ModelAction, users []string, access string, expires *time.Time, modelUUIDs []string) error {
+	var args params.ModifyModelAccessRequest
+
+	hasGroups := false
+	for _, user := range users {
+		if group, ok := groupName(user); ok {
+			if group == "" {
+				return errors.Errorf("invalid group name: %q", user)
+			}
+			if expires != nil {
+				return errors.NotSupportedf("expiring group access")
+			}
+			hasGroups = true
+			continue
+		}
+		if !names.IsValidUser(user) {
+			return errors.Errorf("invalid username: %q", user)
+		}
+	}
+	if hasGroups && c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("changing group access on this controller")
+	}
 	modelAccess := permission.Access(access)
 	if err := permission.ValidateModelAccess(modelAccess); err != nil {
 		return errors.Trace(err)
 	}
-	for _, model := range modelUUIDs {
-		if !names.IsValidModel(model) {
-			return errors.Errorf("invalid model: %q", model)
+	type userModel struct {
+		user, model string
+	}
+	var changes []userModel
+	for _, user := range users {
+		group, isGroup := groupName(user)
+		for _, model := range modelUUIDs {
+			if !names.IsValidModel(model) {
+				return errors.Errorf("invalid model: %q", model)
+			}
+			change := params.ModifyModelAccess{
+				Action:   action,
+				Access:   params.UserAccessPermission(modelAccess),
+				ModelTag: names.NewModelTag(model).String(),
+				Expires:  expires,
+			}
+			if isGroup {
+				change.Group = group
+				changes = append(changes, userModel{user, model})
+			} else {
+				userTag := names.NewUserTag(user)
+				change.UserTag = userTag.String()
+				changes = append(changes, userModel{userTag.Id(), model})
+			}
+			args.Changes = append(args.Changes, change)
 		}
-		modelTag := names.NewModelTag(model)
-		args.Changes = append(args.Changes, params.ModifyModelAccess{
-			UserTag:  userTag.String(),
-			Action:   action,
-			Access:   params.UserAccessPermission(modelAccess),
-			ModelTag: modelTag.String(),
-		})
 	}
 
 	var result params.ErrorResults
@@ -303,11 +376,101 @@ func (c *Client) modifyModelUser(action params.ModelAction, user, access string,
 
 	for i, r := range result.Results {
 		if r.Error != nil && r.Error.Code == params.CodeAlreadyExists {
-			logger.Warningf("model %q is already shared with %q", modelUUIDs[i], userTag.Id())
+			logger.Warningf("model %q is already shared with %q", changes[i].model, changes[i].user)
 			result.Results[i].Error = nil
 		}
 	}
-	return result.Combine()
+	if len(users) == 1 {
+		return result.Combine()
+	}
+	var failures []string
+	for i, r := range result.Results {
+		if r.Error != nil {
+			failures = append(failures, fmt.Sprintf("user %q, model %q: %v", changes[i].user, changes[i].model, r.Error))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// GrantApplications grants each of the users write access to the named
+// applications in the model, giving them read access to the model if
+// they do not already have it.
+func (c *Client) GrantApplications(users []string, modelUUID string, applications ...string) error {
+	return c.modifyApplicationUsers(params.GrantModelAccess, users, modelUUID, applications)
+}
+
+// RevokeApplications revokes each of the users' write access to the
+// named applications in the model. Their access to the model as a whole
+// is left unchanged.
+func (c *Client) RevokeApplications(users []string, modelUUID string, applications ...string) error {
+	return c.modifyApplicationUsers(params.RevokeModelAccess, users, modelUUID, applications)
+}
+
+func (c *Client) modifyApplicationUsers(action params.ModelAction, users []string, modelUUID string, applications []string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("changing application access on this controller")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.Errorf("invalid model: %q", modelUUID)
+	}
+	if len(applications) == 0 {
+		return errors.New("no applications specified")
+	}
+	for _, application := range applications {
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("invalid application name: %q", application)
+		}
+	}
+	var args params.ModifyModelAccessRequest
+	for _, user := range users {
+		if _, ok := groupName(user); ok {
+			return errors.NotSupportedf("application access for groups")
+		}
+		if !names.IsValidUser(user) {
+			return errors.Errorf("invalid username: %q", user)
+		}
+		args.Changes = append(args.Changes, params.ModifyModelAccess{
+			UserTag:      names.NewUserTag(user).String(),
+			Action:       action,
+			Access:       params.ModelWriteAccess,
+			ModelTag:     names.NewModelTag(modelUUID).String(),
+			Applications: applications,
+		})
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyModelAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	if len(users) == 1 {
+		return result.Combine()
+	}
+	var failures []string
+	for i, r := range result.Results {
+		if r.Error != nil {
+			failures = append(failures, fmt.Sprintf("user %q: %v", users[i], r.Error))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// groupName returns the name of the group if user names a group with
+// an "@" prefix.
+func groupName(user string) (string, bool) {
+	if !strings.HasPrefix(user, "@") {
+		return "", false
+	}
+	return user[1:], true
 }
 
 // ModelDefaults returns the default values for various sources used when
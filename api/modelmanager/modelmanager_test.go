@@ -102,8 +102,11 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
 		func(req string, args interface{}, resp interface{}) error {
 			c.Assert(req, gc.Equals, "DestroyModels")
-			c.Assert(args, jc.DeepEquals, params.Entities{
-				Entities: []params.Entity{{testing.ModelTag.String()}},
+			c.Assert(args, jc.DeepEquals, params.DestroyModelsParams{
+				Models: []params.DestroyModelParams{{
+					ModelTag:       testing.ModelTag.String(),
+					DestroyStorage: true,
+				}},
 			})
 			results := resp.(*params.ErrorResults)
 			*results = params.ErrorResults{
@@ -113,9 +116,36 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 			return nil
 		})
 
-	err := modelManager.DestroyModel(testing.ModelTag)
+	err := modelManager.DestroyModel(testing.ModelTag, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestDestroyModelV4(c *gc.C) {
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "DestroyModels")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{testing.ModelTag.String()}},
+			})
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+		version: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.DestroyModel(testing.ModelTag, true)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
+
+	called = false
+	err = client.DestroyModel(testing.ModelTag, false)
+	c.Assert(err, gc.ErrorMatches, "this controller always destroys a model's storage along with it; use --destroy-storage to destroy the model and its storage")
+	c.Assert(called, jc.IsFalse)
 }
 
 func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
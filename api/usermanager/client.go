@@ -143,6 +143,11 @@ func (c *Client) UserInfo(usernames []string, all IncludeDisabled) ([]params.Use
 		for i, result := range results.Results {
 			if result.Error != nil {
 				annotated := errors.Annotate(result.Error, usernames[i])
+				if len(usernames) == 1 {
+					// Keep the error code, so that callers can
+					// tell whether the user was found.
+					return nil, annotated
+				}
 				errorStrings = append(errorStrings, annotated.Error())
 			}
 		}
@@ -180,3 +185,61 @@ func (c *Client) SetPassword(username, password string) error {
 	}
 	return results.OneError()
 }
+
+// AddGroup creates a new group, with no members, in the controller.
+func (c *Client) AddGroup(name string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("groups on this controller")
+	}
+	args := params.AddGroups{
+		Groups: []params.AddGroup{{Name: name}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddGroup", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AddGroupMembers adds the users to the named group.
+func (c *Client) AddGroupMembers(group string, usernames ...string) error {
+	return c.groupMembersCall("AddGroupMembers", group, usernames)
+}
+
+// RemoveGroupMembers removes the users from the named group.
+func (c *Client) RemoveGroupMembers(group string, usernames ...string) error {
+	return c.groupMembersCall("RemoveGroupMembers", group, usernames)
+}
+
+func (c *Client) groupMembersCall(methodCall, group string, usernames []string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("groups on this controller")
+	}
+	var args params.GroupMembers
+	for _, username := range usernames {
+		if !names.IsValidUser(username) {
+			return errors.Errorf("%q is not a valid username", username)
+		}
+		args.Changes = append(args.Changes, params.GroupMember{
+			Group:   group,
+			UserTag: names.NewUserTag(username).String(),
+		})
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(methodCall, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(results.Results))
+	}
+	var failures []string
+	for i, result := range results.Results {
+		if result.Error != nil {
+			failures = append(failures, fmt.Sprintf("user %q: %v", usernames[i], result.Error))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/apiserver/params"
@@ -193,6 +194,12 @@ func (s *usermanagerSuite) TestUserInfoMoreThanOneError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "foo: first error, bar: second error")
 }
 
+func (s *usermanagerSuite) TestUserInfoNotFound(c *gc.C) {
+	_, err := s.usermanager.UserInfo([]string{"nobody"}, usermanager.AllUsers)
+	c.Assert(err, gc.ErrorMatches, `nobody: user "nobody" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func (s *usermanagerSuite) TestSetUserPassword(c *gc.C) {
 	tag := s.AdminUserTag(c)
 	err := s.usermanager.SetPassword(tag.Name(), "new-password")
@@ -215,3 +222,37 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not!good", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestAddGroup(c *gc.C) {
+	err := s.usermanager.AddGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.CreatedBy(), gc.Equals, s.AdminUserTag(c).Id())
+
+	err = s.usermanager.AddGroup("devs")
+	c.Assert(err, gc.ErrorMatches, `failed to create group: group "devs" already exists`)
+}
+
+func (s *usermanagerSuite) TestGroupMembers(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	err := s.usermanager.AddGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.usermanager.AddGroupMembers("devs", "foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{user.UserTag()})
+
+	err = s.usermanager.RemoveGroupMembers("devs", "foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Refresh(), jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *usermanagerSuite) TestAddGroupMembersMissingGroup(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	err := s.usermanager.AddGroupMembers("devs", "foobar")
+	c.Assert(err, gc.ErrorMatches, `user "foobar": group "devs" not found`)
+}
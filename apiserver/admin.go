@@ -83,6 +83,7 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			// Users are not rate limited, all other entities are.
 			if !a.srv.limiter.Acquire() {
 				logger.Debugf("rate limiting for agent %s", req.AuthTag)
+				a.srv.connLimits.rejectLoginRateLimited()
 				return fail, common.ErrTryAgain
 			}
 			defer a.srv.limiter.Release()
@@ -135,9 +136,34 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		// worker for the controller model.
 		controllerMachineLogin = true
 	}
+	if userTag, ok := entity.Tag().(names.UserTag); ok && userTag.IsLocal() {
+		// Password logins already fail for disabled users, but macaroon
+		// logins do not look at the user document, so check here. Every
+		// later request is checked too, so that disabling a user ends
+		// their existing sessions.
+		active, err := isUserActive(a.root.state, userTag)
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+		if !active {
+			return fail, errors.Trace(common.ErrBadCreds)
+		}
+		apiRoot = restrictRoot(apiRoot, activeUserOnly(a.root.state, userTag, a.srv.clock))
+	}
 	a.root.entity = entity
 	a.apiObserver.Login(entity.Tag(), a.root.state.ModelTag(), controllerMachineLogin, req.UserData)
 
+	if !isUser && !controllerMachineLogin {
+		// Controller machine agents are not counted against the
+		// agent connection quota: they need to reach every model
+		// regardless of how busy the controller is.
+		slot, err := a.srv.connLimits.acquireAgent(entity.Tag())
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+		a.root.getResources().Register(slot)
+	}
+
 	// We have authenticated the user; enable the appropriate API
 	// to serve to them.
 	a.loggedIn = true
@@ -157,6 +183,12 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			return fail, errors.Trace(err)
 		}
 		maybeUserInfo.LastConnection = lastConnection
+		superuser := maybeUserInfo.ControllerAccess == string(permission.SuperuserAccess)
+		slot, err := a.srv.connLimits.acquireUser(userTag, superuser)
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+		a.root.getResources().Register(slot)
 	} else {
 		if controllerOnlyLogin {
 			logger.Debugf("controller login: %s", entity.Tag())
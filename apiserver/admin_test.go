@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -168,6 +169,44 @@ func (s *loginSuite) TestLoginAsDeactivatedUser(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestDisablingUserEndsSession(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	cfg := defaultServerConfig(c, s.State)
+	cfg.Clock = clock
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+
+	st := s.openAPIWithoutLogin(c, info)
+	password := "password"
+	u := s.Factory.MakeUser(c, &factory.UserParams{Password: password})
+	err := st.Login(u.Tag(), password, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.Client().Status([]string{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Disable()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The user's state is not read again until the check interval
+	// has passed.
+	_, err = st.Client().Status([]string{})
+	c.Assert(err, jc.ErrorIsNil)
+	clock.Advance(5 * time.Second)
+	_, err = st.Client().Status([]string{})
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "login expired",
+		Code:    "login expired",
+	})
+
+	// The session is usable again once the user is re-enabled.
+	err = u.Enable()
+	c.Assert(err, jc.ErrorIsNil)
+	clock.Advance(5 * time.Second)
+	_, err = st.Client().Status([]string{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *baseLoginSuite) runLoginSetsLogIdentifier(c *gc.C) {
 	info, srv := newServer(c, s.State)
 	defer assertStop(c, srv)
@@ -458,6 +497,114 @@ func (s *loginSuite) TestUsersAreNotRateLimited(c *gc.C) {
 	}
 }
 
+func (s *loginSuite) TestAgentConnectionQuota(c *gc.C) {
+	cfg := defaultServerConfig(c, s.State)
+	cfg.MaxAgentConnections = 1
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+
+	machine0, password0 := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	machine1, password1 := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	info.ModelTag = s.State.ModelTag()
+	info.Nonce = "fake_nonce"
+	info0, info1 := *info, *info
+	info0.Tag, info0.Password = machine0.Tag(), password0
+	info1.Tag, info1.Password = machine1.Tag(), password1
+
+	st0, err := api.Open(&info0, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Open(&info1, fastDialOpts)
+	c.Assert(err, jc.Satisfies, params.IsCodeTryAgain)
+
+	// Users are not counted against the agent quota.
+	userInfo := *info
+	userInfo.Tag = s.AdminUserTag(c)
+	userInfo.Password = "dummy-secret"
+	userInfo.Nonce = ""
+	st, err := api.Open(&userInfo, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	st.Close()
+
+	// Once the first agent disconnects its slot is released.
+	st0.Close()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		st, err = api.Open(&info1, fastDialOpts)
+		if !params.IsCodeTryAgain(err) {
+			break
+		}
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	st.Close()
+}
+
+func (s *loginSuite) TestUserConnectionQuota(c *gc.C) {
+	cfg := defaultServerConfig(c, s.State)
+	cfg.MaxUserConnections = 1
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password"})
+	userInfo := *info
+	userInfo.Tag = user.UserTag()
+	userInfo.Password = "dummy-password"
+	st, err := api.Open(&userInfo, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	_, err = api.Open(&userInfo, fastDialOpts)
+	c.Assert(err, jc.Satisfies, params.IsCodeTryAgain)
+
+	// Controller superusers are never limited.
+	adminInfo := *info
+	adminInfo.Tag = s.AdminUserTag(c)
+	adminInfo.Password = "dummy-secret"
+	for i := 0; i < 3; i++ {
+		st, err := api.Open(&adminInfo, fastDialOpts)
+		c.Assert(err, jc.ErrorIsNil)
+		defer st.Close()
+	}
+}
+
+func (s *loginSuite) TestUserLoginRateLimit(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	cfg := defaultServerConfig(c, s.State)
+	cfg.Clock = clock
+	cfg.UserLoginRateLimit = 2
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password"})
+	userInfo := *info
+	userInfo.Tag = user.UserTag()
+	userInfo.Password = "dummy-password"
+	for i := 0; i < 2; i++ {
+		st, err := api.Open(&userInfo, fastDialOpts)
+		c.Assert(err, jc.ErrorIsNil)
+		st.Close()
+	}
+	_, err := api.Open(&userInfo, fastDialOpts)
+	c.Assert(err, jc.Satisfies, params.IsCodeTryAgain)
+
+	// Controller superusers are never limited.
+	adminInfo := *info
+	adminInfo.Tag = s.AdminUserTag(c)
+	adminInfo.Password = "dummy-secret"
+	for i := 0; i < 3; i++ {
+		st, err := api.Open(&adminInfo, fastDialOpts)
+		c.Assert(err, jc.ErrorIsNil)
+		st.Close()
+	}
+
+	// The user may log in again once the minute has passed.
+	clock.Advance(time.Minute)
+	st, err := api.Open(&userInfo, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	st.Close()
+}
+
 func (s *loginSuite) TestNonModelUserLoginFails(c *gc.C) {
 	info, srv := newServer(c, s.State)
 	defer assertStop(c, srv)
@@ -472,6 +619,42 @@ func (s *loginSuite) TestNonModelUserLoginFails(c *gc.C) {
 	assertInvalidEntityPassword(c, err)
 }
 
+func (s *loginSuite) TestGroupMemberModelLogin(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password", NoModelUser: true})
+	ctag := names.NewControllerTag(s.State.ControllerUUID())
+	err := s.State.RemoveUserAccess(user.UserTag(), ctag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddGroupMember("devs", user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.Password = "dummy-password"
+	info.Tag = user.UserTag()
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// Read access through the group allows read-only calls...
+	err = st.APICall("Client", 1, "", "FullStatus", params.StatusParams{}, new(params.FullStatus))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// ...but not writes.
+	err = st.APICall("ModelConfig", 1, "", "ModelSet", params.ModelSet{}, nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.APICall("ModelConfig", 1, "", "ModelSet", params.ModelSet{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *loginSuite) TestLoginValidationSuccess(c *gc.C) {
 	validator := func(params.LoginRequest) error {
 		return nil
@@ -23,6 +23,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Agent", 2, NewAgentAPIV2)
+
+	// Version 3 adds ModelCredential.
+	common.RegisterStandardFacade("Agent", 3, NewAgentAPIV3)
 }
 
 // AgentAPIV2 implements the version 2 of the API provided to an agent.
@@ -61,6 +64,21 @@ func NewAgentAPIV2(st *state.State, resources facade.Resources, auth facade.Auth
 	}, nil
 }
 
+// AgentAPIV3 implements version 3 of the API provided to an agent.
+type AgentAPIV3 struct {
+	*AgentAPIV2
+}
+
+// NewAgentAPIV3 returns an object implementing version 3 of the Agent API
+// with the given authorizer representing the currently logged in client.
+func NewAgentAPIV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV3, error) {
+	api, err := NewAgentAPIV2(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &AgentAPIV3{api}, nil
+}
+
 func (api *AgentAPIV2) GetEntities(args params.Entities) params.AgentGetEntitiesResults {
 	results := params.AgentGetEntitiesResults{
 		Entities: make([]params.AgentGetEntitiesResult, len(args.Entities)),
@@ -186,3 +204,21 @@ func (api *AgentAPIV2) WatchCredentials(args params.Entities) (params.NotifyWatc
 	}
 	return results, nil
 }
+
+// ModelCredential returns the tag of the cloud credential used by the
+// model. Controller agents use it, along with WatchCredentials, to pick
+// up changes to the credential without restarting.
+func (api *AgentAPIV3) ModelCredential() (params.ModelCredential, error) {
+	if !api.auth.AuthController() {
+		return params.ModelCredential{}, common.ErrPerm
+	}
+	model, err := api.st.Model()
+	if err != nil {
+		return params.ModelCredential{}, errors.Trace(err)
+	}
+	var result params.ModelCredential
+	if tag, ok := model.CloudCredential(); ok {
+		result.CloudCredential = tag.String()
+	}
+	return result, nil
+}
@@ -276,3 +276,28 @@ func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
+
+func (s *agentSuite) TestModelCredential(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	tag, ok := model.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+
+	result, err := api.ModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelCredential{CloudCredential: tag.String()})
+}
+
+func (s *agentSuite) TestModelCredentialAuthError(c *gc.C) {
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ModelCredential()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/pubsub"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"gopkg.in/juju/names.v2"
@@ -46,8 +47,8 @@ var logger = loggo.GetLogger("juju.apiserver")
 
 var defaultHTTPMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "OPTIONS"}
 
-// loginRateLimit defines how many concurrent Login requests we will
-// accept
+// loginRateLimit defines how many concurrent agent Login requests we
+// will accept if ServerConfig.AgentLoginRateLimit is not set.
 const loginRateLimit = 10
 
 // Server holds the server side of the API.
@@ -63,6 +64,7 @@ type Server struct {
 	dataDir           string
 	logDir            string
 	limiter           utils.Limiter
+	connLimits        *connectionLimits
 	validator         LoginValidator
 	adminAPIFactories map[int]adminAPIFactory
 	modelUUID         string
@@ -125,6 +127,31 @@ type ServerConfig struct {
 	// they don't have access to the controller.
 	AllowModelAccess bool
 
+	// AgentLoginRateLimit holds the number of agent logins that
+	// will be processed concurrently; further agent logins are
+	// asked to try again later. If this is zero, a default of 10
+	// is used. Users are never rate limited.
+	AgentLoginRateLimit int
+
+	// MaxAgentConnections holds the maximum number of concurrent
+	// logged in agent connections. Controller machine agents are
+	// not counted. If this is zero, there is no limit.
+	MaxAgentConnections int
+
+	// MaxUserConnections holds the maximum number of concurrent
+	// connections any one user may hold. Controller superusers
+	// are not limited. If this is zero, there is no limit.
+	MaxUserConnections int
+
+	// UserLoginRateLimit holds the maximum number of logins any
+	// one user may make per minute. Controller superusers are not
+	// limited. If this is zero, there is no limit.
+	UserLoginRateLimit int
+
+	// PrometheusRegisterer, if non-nil, is used to register the
+	// connection quota metrics.
+	PrometheusRegisterer prometheus.Registerer
+
 	// NewObserver is a function which will return an observer. This
 	// is used per-connection to instantiate a new observer to be
 	// notified of key events during API requests.
@@ -153,10 +180,29 @@ func (c *ServerConfig) Validate() error {
 	if c.StatePool == nil {
 		return errors.NotValidf("missing StatePool")
 	}
+	if c.AgentLoginRateLimit < 0 {
+		return errors.NotValidf("negative AgentLoginRateLimit")
+	}
+	if c.MaxAgentConnections < 0 {
+		return errors.NotValidf("negative MaxAgentConnections")
+	}
+	if c.MaxUserConnections < 0 {
+		return errors.NotValidf("negative MaxUserConnections")
+	}
+	if c.UserLoginRateLimit < 0 {
+		return errors.NotValidf("negative UserLoginRateLimit")
+	}
 
 	return nil
 }
 
+func (c *ServerConfig) loginRateLimit() int {
+	if c.AgentLoginRateLimit == 0 {
+		return loginRateLimit
+	}
+	return c.AgentLoginRateLimit
+}
+
 func (c *ServerConfig) pingClock() clock.Clock {
 	if c.PingClock == nil {
 		return c.Clock
@@ -204,7 +250,7 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 		tag:         cfg.Tag,
 		dataDir:     cfg.DataDir,
 		logDir:      cfg.LogDir,
-		limiter:     utils.NewLimiter(loginRateLimit),
+		limiter:     utils.NewLimiter(cfg.loginRateLimit()),
 		validator:   cfg.Validator,
 		adminAPIFactories: map[int]adminAPIFactory{
 			3: newAdminAPIV3,
@@ -215,6 +261,20 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
 	}
 
+	metrics := newConnectionMetrics()
+	if cfg.PrometheusRegisterer != nil {
+		if err := metrics.register(cfg.PrometheusRegisterer); err != nil {
+			return nil, errors.Annotate(err, "registering connection metrics")
+		}
+	}
+	srv.connLimits = newConnectionLimits(
+		cfg.MaxAgentConnections,
+		cfg.MaxUserConnections,
+		cfg.UserLoginRateLimit,
+		cfg.Clock,
+		metrics,
+	)
+
 	srv.tlsConfig = srv.newTLSConfig(cfg)
 	srv.lis = tls.NewListener(lis, srv.tlsConfig)
 
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -43,6 +44,12 @@ func init() {
 	// methods, superseding the existing DestroyUnits and
 	// Destroy methods respectively.
 	common.RegisterStandardFacade("Application", 4, newAPI)
+	// Version 5 adds the ExposeEndpoints method.
+	common.RegisterStandardFacade("Application", 5, newAPI)
+	// Version 6 adds the AddApplicationUnits, SetApplicationsConfig,
+	// ExposeApplications and AddRelations methods, which operate on
+	// many applications in one call.
+	common.RegisterStandardFacade("Application", 6, newAPI)
 }
 
 // API implements the application interface and is the concrete
@@ -133,9 +140,56 @@ func (api *API) checkCanWrite() error {
 	return nil
 }
 
+// applicationWriteChecker returns a function that reports whether the
+// authenticated user may change the named application. Users with write
+// access to the model may change any application; other users of the
+// model may only change the applications they have been granted write
+// access to.
+func (api *API) applicationWriteChecker() (func(string) error, error) {
+	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, api.backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if canWrite {
+		return func(string) error { return nil }, nil
+	}
+	if err := api.checkCanRead(); err != nil {
+		return nil, err
+	}
+	userTag, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	return func(appName string) error {
+		access, err := api.backend.ApplicationAccess(userTag, appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if access != permission.WriteAccess {
+			return common.ErrPerm
+		}
+		return nil
+	}, nil
+}
+
+// author returns the authenticated entity's tag, which is recorded
+// against the config changes it makes.
+func (api *API) author() string {
+	return api.authorizer.GetAuthTag().String()
+}
+
+func (api *API) checkCanWriteApplication(appName string) error {
+	check, err := api.applicationWriteChecker()
+	if err != nil {
+		return err
+	}
+	return check(appName)
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	checkCanWrite, err := api.applicationWriteChecker()
+	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 	result := params.ErrorResults{
@@ -145,6 +199,10 @@ func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (
 		return result, nil
 	}
 	for i, a := range args.Creds {
+		if err := checkCanWrite(a.ApplicationName); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		application, err := api.backend.Application(a.ApplicationName)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
@@ -242,8 +300,9 @@ func deployApplication(
 }
 
 // ApplicationSetSettingsStrings updates the settings for the given application,
-// taking the configuration from a map of strings.
-func ApplicationSetSettingsStrings(application Application, settings map[string]string) error {
+// taking the configuration from a map of strings. The change is recorded
+// as made by the given author.
+func ApplicationSetSettingsStrings(application Application, author string, settings map[string]string) error {
 	ch, _, err := application.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -253,7 +312,7 @@ func ApplicationSetSettingsStrings(application Application, settings map[string]
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateConfigSettings(changes)
+	return application.UpdateConfigSettingsBy(author, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -293,7 +352,7 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 // minimum number of units, settings and constraints.
 // All parameters in params.ApplicationUpdate except the application name are optional.
 func (api *API) Update(args params.ApplicationUpdate) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return err
 	}
 	if !args.ForceCharmURL {
@@ -333,11 +392,11 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
-		if err = applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
+		if err = applicationSetSettingsYAML(args.ApplicationName, app, api.author(), args.SettingsYAML); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 	} else if len(args.SettingsStrings) > 0 {
-		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
+		if err = ApplicationSetSettingsStrings(app, api.author(), args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -350,7 +409,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 
 // SetCharm sets the charm for a given for the application.
 func (api *API) SetCharm(args params.ApplicationSetCharm) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return err
 	}
 	// when forced units in error, don't block
@@ -430,6 +489,7 @@ func (api *API) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		Author:             api.author(),
 	}
 	return application.SetCharm(cfg)
 }
@@ -463,8 +523,9 @@ func settingsFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, e
 }
 
 // applicationSetSettingsYAML updates the settings for the given application,
-// taking the configuration from a YAML string.
-func applicationSetSettingsYAML(appName string, application Application, settings string) error {
+// taking the configuration from a YAML string. The change is recorded as
+// made by the given author.
+func applicationSetSettingsYAML(appName string, application Application, author, settings string) error {
 	b := []byte(settings)
 	var all map[string]interface{}
 	if err := goyaml.Unmarshal(b, &all); err != nil {
@@ -476,7 +537,7 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 		if err != nil {
 			return errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings with application YAML")
+		return errors.Annotate(application.UpdateConfigSettingsBy(author, changes), "updating settings with application YAML")
 	}
 
 	ch, _, err := application.Charm()
@@ -488,13 +549,13 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 	if err != nil {
 		return errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings")
+	return errors.Annotate(application.UpdateConfigSettingsBy(author, changes), "updating settings")
 }
 
 // GetCharmURL returns the charm URL the given application is
 // running at present.
 func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, error) {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return params.StringResult{}, errors.Trace(err)
 	}
 	application, err := api.backend.Application(args.ApplicationName)
@@ -509,12 +570,37 @@ func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, er
 // It does not unset values that are set to an empty string.
 // Unset should be used for that.
 func (api *API) Set(p params.ApplicationSet) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(p.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	return api.setConfig(p)
+}
+
+// SetApplicationsConfig sets configuration options on each of the
+// given applications, as Set does for one.
+func (api *API) SetApplicationsConfig(args params.ApplicationSetArgs) (params.ErrorResults, error) {
+	checkCanWrite, err := api.applicationWriteChecker()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := checkCanWrite(arg.ApplicationName)
+		if err == nil {
+			err = api.setConfig(arg)
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) setConfig(p params.ApplicationSet) error {
 	app, err := api.backend.Application(p.ApplicationName)
 	if err != nil {
 		return err
@@ -529,13 +615,13 @@ func (api *API) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return app.UpdateConfigSettings(changes)
+	return app.UpdateConfigSettingsBy(api.author(), changes)
 
 }
 
 // Unset implements the server side of Client.Unset.
 func (api *API) Unset(p params.ApplicationUnset) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(p.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -549,7 +635,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return app.UpdateConfigSettings(settings)
+	return app.UpdateConfigSettingsBy(api.author(), settings)
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -577,23 +663,79 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Expose(args params.ApplicationExpose) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	app, err := api.backend.Application(args.ApplicationName)
+	return api.expose(args.ApplicationName)
+}
+
+// ExposeApplications exposes each of the given applications, as Expose
+// does for one.
+func (api *API) ExposeApplications(args params.Entities) (params.ErrorResults, error) {
+	checkCanWrite, err := api.applicationWriteChecker()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err == nil {
+			err = checkCanWrite(tag.Id())
+		}
+		if err == nil {
+			err = api.expose(tag.Id())
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) expose(appName string) error {
+	app, err := api.backend.Application(appName)
 	if err != nil {
 		return err
 	}
 	return app.SetExposed()
 }
 
+// ExposeEndpoints changes the juju-managed firewall to expose only the
+// given port ranges of the named endpoints of an application.
+func (api *API) ExposeEndpoints(args params.ApplicationExposeEndpoints) error {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	endpoints := make(map[string][]network.PortRange, len(args.Endpoints))
+	for name, specs := range args.Endpoints {
+		portRanges := make([]network.PortRange, len(specs))
+		for i, spec := range specs {
+			portRange, err := network.ParsePortRange(spec)
+			if err != nil {
+				return errors.Annotatef(err, "endpoint %q", name)
+			}
+			portRanges[i] = portRange
+		}
+		endpoints[name] = portRanges
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.ExposeEndpoints(endpoints)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -620,7 +762,7 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*s
 
 // AddUnits adds a given number of units to an application.
 func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -637,6 +779,35 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	return params.AddApplicationUnitsResults{Units: unitNames}, nil
 }
 
+// AddApplicationUnits adds units to each of the given applications, as
+// AddUnits does for one.
+func (api *API) AddApplicationUnits(args params.AddApplicationUnitsArgs) (params.AddApplicationUnitsResultList, error) {
+	checkCanWrite, err := api.applicationWriteChecker()
+	if err != nil {
+		return params.AddApplicationUnitsResultList{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.AddApplicationUnitsResultList{}, errors.Trace(err)
+	}
+	results := make([]params.AddApplicationUnitsResult, len(args.Args))
+	for i, arg := range args.Args {
+		if err := checkCanWrite(arg.ApplicationName); err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		units, err := addApplicationUnits(api.backend, arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Units = make([]string, len(units))
+		for j, unit := range units {
+			results[i].Units[j] = unit.String()
+		}
+	}
+	return params.AddApplicationUnitsResultList{Results: results}, nil
+}
+
 // DestroyUnits removes a given set of application units.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -673,7 +844,8 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 
 // DestroyUnit removes a given set of application units.
 func (api *API) DestroyUnit(args params.Entities) (params.DestroyUnitResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	checkCanWrite, err := api.applicationWriteChecker()
+	if err != nil {
 		return params.DestroyUnitResults{}, err
 	}
 	if err := api.check.RemoveAllowed(); err != nil {
@@ -685,6 +857,13 @@ func (api *API) DestroyUnit(args params.Entities) (params.DestroyUnitResults, er
 			return nil, err
 		}
 		name := unitTag.Id()
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCanWrite(appName); err != nil {
+			return nil, err
+		}
 		unit, err := api.backend.Unit(name)
 		if errors.IsNotFound(err) {
 			return nil, errors.Errorf("unit %q does not exist", name)
@@ -746,7 +925,8 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 
 // DestroyApplication removes a given set of applications.
 func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicationResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	checkCanWrite, err := api.applicationWriteChecker()
+	if err != nil {
 		return params.DestroyApplicationResults{}, err
 	}
 	if err := api.check.RemoveAllowed(); err != nil {
@@ -764,6 +944,9 @@ func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicat
 		if err != nil {
 			return nil, err
 		}
+		if err := checkCanWrite(tag.Id()); err != nil {
+			return nil, err
+		}
 		var info params.DestroyApplicationInfo
 		if err := destroyRemoteApp(tag.Id()); !errors.IsNotFound(err) {
 			return &info, err
@@ -836,7 +1019,7 @@ func (api *API) GetConstraints(args params.GetApplicationConstraints) (params.Ge
 
 // SetConstraints sets the constraints for a given application.
 func (api *API) SetConstraints(args params.SetConstraints) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -861,11 +1044,39 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
+	endpoints, err := api.addRelation(args.Endpoints)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	return params.AddRelationResults{Endpoints: endpoints}, nil
+}
 
-	endpoints := make([]string, len(args.Endpoints))
+// AddRelations adds each of the given relations, as AddRelation does
+// for one.
+func (api *API) AddRelations(args params.AddRelations) (params.AddRelationsResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.AddRelationsResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.AddRelationsResults{}, errors.Trace(err)
+	}
+	results := make([]params.AddRelationResult, len(args.Relations))
+	for i, arg := range args.Relations {
+		endpoints, err := api.addRelation(arg.Endpoints)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Endpoints = endpoints
+	}
+	return params.AddRelationsResults{Results: results}, nil
+}
+
+func (api *API) addRelation(args []string) (map[string]params.CharmRelation, error) {
+	endpoints := make([]string, len(args))
 	// We may have a remote application passed in as the endpoint spec.
 	// We'll iterate the endpoints to check.
-	for i, ep := range args.Endpoints {
+	for i, ep := range args {
 		endpoints[i] = ep
 
 		// If cross model relations not enabled, ignore remote endpoints.
@@ -892,7 +1103,7 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 		alias := url.ApplicationName
 		remoteApp, err := api.processRemoteApplication(url, alias)
 		if err != nil {
-			return params.AddRelationResults{}, errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		// The endpoint is named after the remote application name,
 		// not the application name from the URL.
@@ -904,18 +1115,18 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 
 	inEps, err := api.backend.InferEndpoints(endpoints...)
 	if err != nil {
-		return params.AddRelationResults{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	rel, err := api.backend.AddRelation(inEps...)
 	if err != nil {
-		return params.AddRelationResults{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	outEps := make(map[string]params.CharmRelation)
 	for _, inEp := range inEps {
 		outEp, err := rel.Endpoint(inEp.ApplicationName)
 		if err != nil {
-			return params.AddRelationResults{}, errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		outEps[inEp.ApplicationName] = params.CharmRelation{
 			Name:      outEp.Relation.Name,
@@ -926,7 +1137,7 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 			Scope:     string(outEp.Relation.Scope),
 		}
 	}
-	return params.AddRelationResults{Endpoints: outEps}, nil
+	return outEps, nil
 }
 
 func (api *API) sameControllerSourceModel(userName, modelName string) (names.ModelTag, error) {
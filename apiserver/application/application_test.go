@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -1483,6 +1484,19 @@ func (s *applicationSuite) TestApplicationSet(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestApplicationSetRecordsAuthor(c *gc.C) {
+	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.Set(params.ApplicationSet{ApplicationName: "dummy", Options: map[string]string{
+		"title": "foobar",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := dummy.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Author, gc.Equals, s.AdminUserTag(c).String())
+}
+
 func (s *applicationSuite) assertApplicationSetBlocked(c *gc.C, dummy *state.Application, msg string) {
 	err := s.applicationAPI.Set(params.ApplicationSet{
 		ApplicationName: "dummy",
@@ -1840,6 +1854,50 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeEndpoints(c *gc.C) {
+	app := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.ExposeEndpoints(params.ApplicationExposeEndpoints{
+		ApplicationName: "dummy-application",
+		Endpoints:       map[string][]string{"juju-info": {"80/tcp", "8000-8080/tcp"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string][]network.PortRange{
+		"juju-info": {
+			network.MustParsePortRange("80/tcp"),
+			network.MustParsePortRange("8000-8080/tcp"),
+		},
+	})
+}
+
+func (s *applicationSuite) TestApplicationExposeEndpointsInvalid(c *gc.C) {
+	s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.ExposeEndpoints(params.ApplicationExposeEndpoints{
+		ApplicationName: "dummy-application",
+		Endpoints:       map[string][]string{"juju-info": {"80-70/tcp"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `endpoint "juju-info": .*`)
+
+	err = s.applicationAPI.ExposeEndpoints(params.ApplicationExposeEndpoints{
+		ApplicationName: "dummy-application",
+		Endpoints:       map[string][]string{"foo": {"80/tcp"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose endpoints of application "dummy-application": application "dummy-application" has no "foo" relation`)
+}
+
+func (s *applicationSuite) TestBlockChangesApplicationExposeEndpoints(c *gc.C) {
+	s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangesApplicationExposeEndpoints")
+	err := s.applicationAPI.ExposeEndpoints(params.ApplicationExposeEndpoints{
+		ApplicationName: "dummy-application",
+		Endpoints:       map[string][]string{"juju-info": {"80/tcp"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesApplicationExposeEndpoints")
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
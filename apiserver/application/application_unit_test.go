@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -81,6 +82,10 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.api = s.newAPI(c)
+}
+
+func (s *ApplicationSuite) newAPI(c *gc.C) *application.API {
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
 	api, err := application.NewAPI(
@@ -97,7 +102,7 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = api
+	return api
 }
 
 func (s *ApplicationSuite) TestSetCharmStorageConstraints(c *gc.C) {
@@ -251,6 +256,100 @@ func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 	}})
 }
 
+func (s *ApplicationSuite) TestApplicationWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	s.backend.applicationAccess = map[string]permission.Access{
+		"foo": permission.WriteAccess,
+	}
+	s.api = s.newAPI(c)
+
+	results, err := s.api.DestroyUnit(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-foo-1"},
+			{Tag: "unit-bar-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{},
+	}, {
+		Error: &params.Error{
+			Code:    params.CodeUnauthorized,
+			Message: "permission denied",
+		},
+	}})
+	s.backend.CheckCallNames(c,
+		"ModelTag", "ModelTag", "ApplicationAccess", "Unit",
+		"UnitStorageAttachments", "ApplicationAccess",
+	)
+}
+
+func (s *ApplicationSuite) TestApplicationWriteAccessDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	s.api = s.newAPI(c)
+
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "foo",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCall(c, 2, "ApplicationAccess", names.NewUserTag("read"), "foo")
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestApplicationWriteAccessNoModelAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	s.backend.applicationAccess = map[string]permission.Access{
+		"foo": permission.WriteAccess,
+	}
+	s.api = s.newAPI(c)
+
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "foo",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag", "ModelTag")
+}
+
+func (s *ApplicationSuite) TestExposeApplications(c *gc.C) {
+	results, err := s.api.ExposeApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-foo"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}, {
+		Error: &params.Error{Message: `"unit-foo-0" is not a valid application tag`},
+	}})
+	s.application.CheckCallNames(c, "SetExposed")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestExposeApplicationsApplicationWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	s.backend.applicationAccess = map[string]permission.Access{
+		"foo": permission.WriteAccess,
+	}
+	s.api = s.newAPI(c)
+
+	results, err := s.api.ExposeApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-foo"},
+			{Tag: "application-bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}, {
+		Error: &params.Error{
+			Code:    params.CodeUnauthorized,
+			Message: "permission denied",
+		},
+	}})
+	s.application.CheckCallNames(c, "SetExposed")
+}
+
 type mockBackend struct {
 	application.Backend
 	testing.Stub
@@ -260,6 +359,7 @@ type mockBackend struct {
 	relation               *mockRelation
 	unitStorageAttachments map[string][]state.StorageAttachment
 	storageInstances       map[string]*mockStorage
+	applicationAccess      map[string]permission.Access
 }
 
 func (b *mockBackend) ModelTag() names.ModelTag {
@@ -268,6 +368,17 @@ func (b *mockBackend) ModelTag() names.ModelTag {
 	return coretesting.ModelTag
 }
 
+func (b *mockBackend) ApplicationAccess(user names.UserTag, appName string) (permission.Access, error) {
+	b.MethodCall(b, "ApplicationAccess", user, appName)
+	if err := b.NextErr(); err != nil {
+		return permission.NoAccess, err
+	}
+	if access, ok := b.applicationAccess[appName]; ok {
+		return access, nil
+	}
+	return permission.NoAccess, nil
+}
+
 func (b *mockBackend) RemoteApplication(name string) (*state.RemoteApplication, error) {
 	b.MethodCall(b, "RemoteApplication", name)
 	return nil, errors.NotFoundf("remote application %q", name)
@@ -375,6 +486,11 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) SetExposed() error {
+	a.MethodCall(a, "SetExposed")
+	return a.NextErr()
+}
+
 func (a *mockApplication) Destroy() error {
 	a.MethodCall(a, "Destroy")
 	return a.NextErr()
@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -21,6 +23,7 @@ type Backend interface {
 	AllModels() ([]Model, error)
 	Application(string) (Application, error)
 	AddApplication(state.AddApplicationArgs) (*state.Application, error)
+	ApplicationAccess(names.UserTag, string) (permission.Access, error)
 	RemoteApplication(name string) (*state.RemoteApplication, error)
 	AddRemoteApplication(args state.AddRemoteApplicationParams) (*state.RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
@@ -60,6 +63,7 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	Endpoints() ([]state.Endpoint, error)
+	ExposeEndpoints(map[string][]network.PortRange) error
 	IsPrincipal() bool
 	Series() string
 	SetCharm(state.SetCharmConfig) error
@@ -67,7 +71,7 @@ type Application interface {
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
-	UpdateConfigSettings(charm.Settings) error
+	UpdateConfigSettingsBy(string, charm.Settings) error
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -22,6 +22,9 @@ import (
 // init registers the Bundle facade.
 func init() {
 	common.RegisterStandardFacade("Bundle", 1, newFacade)
+
+	// Version 2 adds ExportBundle.
+	common.RegisterStandardFacade("Bundle", 2, newFacadeV2)
 }
 
 func newFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
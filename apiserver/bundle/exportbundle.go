@@ -0,0 +1,260 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// BundleV2 defines version 2 of the Bundle API, which adds
+// ExportBundle.
+type BundleV2 interface {
+	Bundle
+
+	// ExportBundle returns a bundle, in YAML format, that reproduces
+	// the applications, machines and relations in the model.
+	ExportBundle() (params.StringResult, error)
+}
+
+func newFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (BundleV2, error) {
+	return NewFacadeV2(st, auth)
+}
+
+// NewFacadeV2 creates and returns a new Bundle API facade, version 2.
+func NewFacadeV2(st *state.State, auth facade.Authorizer) (BundleV2, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &bundleAPIV2{
+		bundleAPI:  &bundleAPI{},
+		st:         st,
+		authorizer: auth,
+	}, nil
+}
+
+// bundleAPIV2 implements the BundleV2 interface.
+type bundleAPIV2 struct {
+	*bundleAPI
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// ExportBundle is part of the BundleV2 interface.
+func (b *bundleAPIV2) ExportBundle() (params.StringResult, error) {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.st.ModelTag())
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	if !canRead {
+		return params.StringResult{}, common.ErrPerm
+	}
+	data, err := exportBundleData(b.st)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	return params.StringResult{Result: string(out)}, nil
+}
+
+// exportBundleData returns bundle data describing the applications
+// in the model, the machines hosting their units, and the relations
+// between them. Application options hold only the values that differ
+// from the charm defaults.
+func exportBundleData(st *state.State) (*charm.BundleData, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+	}
+	machineIds := set.NewStrings()
+	for _, application := range applications {
+		spec, hostIds, err := exportApplication(application)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exporting application %q", application.Name())
+		}
+		data.Applications[application.Name()] = spec
+		machineIds = machineIds.Union(hostIds)
+	}
+
+	if !machineIds.IsEmpty() {
+		data.Machines = make(map[string]*charm.MachineSpec)
+	}
+	for _, id := range machineIds.SortedValues() {
+		machine, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cons, err := machine.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "exporting machine %q", id)
+		}
+		data.Machines[id] = &charm.MachineSpec{
+			Series:      machine.Series(),
+			Constraints: cons.String(),
+		}
+	}
+
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established automatically.
+			continue
+		}
+		pair := []string{
+			endpoints[0].ApplicationName + ":" + endpoints[0].Name,
+			endpoints[1].ApplicationName + ":" + endpoints[1].Name,
+		}
+		sort.Strings(pair)
+		data.Relations = append(data.Relations, pair)
+	}
+	sort.Sort(relationsByEndpoints(data.Relations))
+	return data, nil
+}
+
+// exportOptions returns the application's config settings that differ
+// from the charm's defaults. Settings equal to the defaults are left
+// out, so that the bundle picks up any new defaults of the charm.
+func exportOptions(application *state.Application) (map[string]interface{}, error) {
+	settings, err := application.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := application.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaults := ch.Config().DefaultSettings()
+	options := make(map[string]interface{})
+	for name, value := range settings {
+		if defaultValue, ok := defaults[name]; ok && reflect.DeepEqual(value, defaultValue) {
+			continue
+		}
+		options[name] = value
+	}
+	return options, nil
+}
+
+// exportApplication returns the bundle application spec for the
+// given application, and the ids of the top level machines hosting
+// its units.
+func exportApplication(application *state.Application) (*charm.ApplicationSpec, set.Strings, error) {
+	curl, _ := application.CharmURL()
+	spec := &charm.ApplicationSpec{
+		Charm:  curl.String(),
+		Series: application.Series(),
+		Expose: application.IsExposed(),
+	}
+
+	options, err := exportOptions(application)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(options) > 0 {
+		spec.Options = options
+	}
+	cons, err := application.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+	bindings, err := application.EndpointBindings()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	hostIds := set.NewStrings()
+	if !application.IsPrincipal() {
+		// Subordinate units are placed with their principals.
+		return spec, hostIds, nil
+	}
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByNumber(units))
+	spec.NumUnits = len(units)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		placement, hostId, err := unitPlacement(machineId)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "exporting unit %q", unit.Name())
+		}
+		spec.To = append(spec.To, placement)
+		hostIds.Add(hostId)
+	}
+	return spec, hostIds, nil
+}
+
+// unitPlacement returns the bundle placement directive for a unit
+// assigned to the given machine, and the id of the top level machine
+// hosting it.
+func unitPlacement(machineId string) (string, string, error) {
+	parts := strings.Split(machineId, "/")
+	switch len(parts) {
+	case 1:
+		return machineId, machineId, nil
+	case 3:
+		return parts[1] + ":" + parts[0], parts[0], nil
+	}
+	return "", "", errors.NotSupportedf("placement in nested container %q", machineId)
+}
+
+type unitsByNumber []*state.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i]) < unitNumber(u[j])
+}
+
+func unitNumber(u *state.Unit) int {
+	name := u.Name()
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type exportBundleSuite struct {
+	statetesting.StateSuite
+	facade bundle.BundleV2
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+	facade, err := bundle.NewFacadeV2(s.State, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleSuite) exportBundle(c *gc.C) *charm.BundleData {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *exportBundleSuite) TestExportBundleEmptyModel(c *gc.C) {
+	data := s.exportBundle(c)
+	c.Assert(data.Applications, gc.HasLen, 0)
+	c.Assert(data.Machines, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm:    s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+		Settings: map[string]interface{}{"blog-title": "boring"},
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm:       s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	c.Assert(mysql.SetExposed(), jc.ErrorIsNil)
+
+	wordpressMachine := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: wordpressMachine})
+	mysqlMachine := s.Factory.MakeMachine(c, nil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, mysqlMachine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: container})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpressURL, _ := wordpress.CharmURL()
+	mysqlURL, _ := mysql.CharmURL()
+	data := s.exportBundle(c)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"wordpress": {
+				Charm:    wordpressURL.String(),
+				Series:   "quantal",
+				NumUnits: 1,
+				To:       []string{wordpressMachine.Id()},
+				Options:  map[string]interface{}{"blog-title": "boring"},
+			},
+			"mysql": {
+				Charm:       mysqlURL.String(),
+				Series:      "quantal",
+				NumUnits:    1,
+				To:          []string{"lxd:" + mysqlMachine.Id()},
+				Expose:      true,
+				Constraints: "mem=4096M",
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			wordpressMachine.Id(): {Series: "quantal"},
+			mysqlMachine.Id():     {Series: "quantal"},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	})
+	c.Assert(data.Verify(nil, nil), jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) TestExportBundleOmitsDefaultOptions(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm:    s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+		Settings: map[string]interface{}{"blog-title": "My Title"},
+	})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:     "dummy",
+		Charm:    s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+		Settings: map[string]interface{}{"title": "My Title", "outlook": "fine"},
+	})
+
+	data := s.exportBundle(c)
+	c.Assert(data.Applications["wordpress"].Options, gc.HasLen, 0)
+	c.Assert(data.Applications["dummy"].Options, jc.DeepEquals, map[string]interface{}{
+		"outlook": "fine",
+	})
+}
+
+func (s *exportBundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	facade, err := bundle.NewFacadeV2(s.State, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("nobody"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
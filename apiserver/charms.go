@@ -32,6 +32,10 @@ import (
 
 type FailableHandlerFunc func(http.ResponseWriter, *http.Request) error
 
+// maxCharmArchiveSize is the largest charm archive, in bytes, that may
+// be uploaded.
+var maxCharmArchiveSize int64 = 1 << 30
+
 // CharmsHTTPHandler creates is a http.Handler which serves POST
 // requests to a PostHandler and GET requests to a GetHandler.
 //
@@ -222,11 +226,18 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 		return nil, errors.BadRequestf("expected Content-Type: application/zip, got: %v", contentType)
 	}
 
-	charmFileName, err := writeCharmToTempFile(r.Body)
+	charmFileName, charmSHA256, err := writeCharmToTempFile(r.Body)
+	if charmFileName != "" {
+		defer os.Remove(charmFileName)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	defer os.Remove(charmFileName)
+	// If the client sent the archive's SHA-256 hash, make sure the
+	// archive arrived intact.
+	if expected := query.Get("sha256"); expected != "" && expected != charmSHA256 {
+		return nil, errors.BadRequestf("charm archive SHA-256 mismatch: expected %s, got %s", expected, charmSHA256)
+	}
 
 	err = h.processUploadedArchive(charmFileName)
 	if err != nil {
@@ -505,16 +516,27 @@ func sendBundleContent(
 	return nil
 }
 
-func writeCharmToTempFile(r io.Reader) (string, error) {
+// writeCharmToTempFile writes the uploaded charm archive to a temporary
+// file, returning its name and the hex-encoded SHA-256 hash of its
+// content. Archives larger than maxCharmArchiveSize are rejected; the
+// name of the temporary file is returned even then, so that the caller
+// can remove it.
+func writeCharmToTempFile(r io.Reader) (string, string, error) {
 	tempFile, err := ioutil.TempFile("", "charm")
 	if err != nil {
-		return "", errors.Annotate(err, "creating temp file")
+		return "", "", errors.Annotate(err, "creating temp file")
 	}
 	defer tempFile.Close()
-	if _, err := io.Copy(tempFile, r); err != nil {
-		return "", errors.Annotate(err, "processing upload")
+	hash := sha256.New()
+	limited := io.LimitReader(r, maxCharmArchiveSize+1)
+	n, err := io.Copy(io.MultiWriter(tempFile, hash), limited)
+	if err != nil {
+		return tempFile.Name(), "", errors.Annotate(err, "processing upload")
+	}
+	if n > maxCharmArchiveSize {
+		return tempFile.Name(), "", errors.BadRequestf("charm archive larger than %d bytes", maxCharmArchiveSize)
 	}
-	return tempFile.Name(), nil
+	return tempFile.Name(), hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 func modelIsImporting(st *state.State) (bool, error) {
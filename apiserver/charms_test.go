@@ -22,6 +22,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
+	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
@@ -167,6 +168,26 @@ func (s *charmsSuite) TestUploadFailsWithInvalidZip(c *gc.C) {
 	s.assertErrorResponse(c, resp, http.StatusBadRequest, ".*expected Content-Type: application/zip, got: application/octet-stream$")
 }
 
+func (s *charmsSuite) TestUploadVerifiesSHA256(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	hash, _, err := utils.ReadFileSHA256(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal&sha256=deadbeef"), "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, ".*charm archive SHA-256 mismatch: expected deadbeef, got "+hash+"$")
+
+	resp = s.uploadRequest(c, s.charmsURI(c, "?series=quantal&sha256="+hash), "application/zip", ch.Path)
+	s.assertUploadResponse(c, resp, "local:quantal/dummy-1")
+}
+
+func (s *charmsSuite) TestUploadRejectsLargeArchive(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	s.PatchValue(apiserver.MaxCharmArchiveSize, int64(10))
+
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, ".*charm archive larger than 10 bytes$")
+}
+
 func (s *charmsSuite) TestUploadBumpsRevision(c *gc.C) {
 	// Add the dummy charm with revision 1.
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
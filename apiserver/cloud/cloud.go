@@ -20,6 +20,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Cloud", 1, newFacade)
+
+	// Version 2 adds AddCloud.
+	common.RegisterStandardFacade("Cloud", 2, newFacadeV2)
 }
 
 // CloudAPI implements the model manager interface and is
@@ -35,6 +38,30 @@ func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Cl
 	return NewCloudAPI(NewStateBackend(st), auth)
 }
 
+// CloudAPIV2 provides version 2 of the Cloud facade, which adds
+// AddCloud.
+type CloudAPIV2 struct {
+	*CloudAPI
+}
+
+func newFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*CloudAPIV2, error) {
+	api, err := newFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CloudAPIV2{api}, nil
+}
+
+// NewCloudAPIV2 creates a new API server endpoint for version 2 of the
+// Cloud facade.
+func NewCloudAPIV2(backend Backend, authorizer facade.Authorizer) (*CloudAPIV2, error) {
+	api, err := NewCloudAPI(backend, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CloudAPIV2{api}, nil
+}
+
 // NewCloudAPI creates a new API server endpoint for managing the controller's
 // cloud definition and cloud credentials.
 func NewCloudAPI(backend Backend, authorizer facade.Authorizer) (*CloudAPI, error) {
@@ -107,6 +134,48 @@ func (api *CloudAPI) Cloud(args params.Entities) (params.CloudResults, error) {
 	return results, nil
 }
 
+// AddCloud adds a new cloud definition to the controller, so that
+// models may be created in it. Only controller superusers may add
+// clouds.
+func (api *CloudAPIV2) AddCloud(args params.AddCloudArgs) error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	if !names.IsValidCloud(args.Name) {
+		return errors.NotValidf("cloud name %q", args.Name)
+	}
+	return api.backend.AddCloud(cloudFromParams(args.Name, args.Cloud))
+}
+
+func cloudFromParams(cloudName string, p params.Cloud) cloud.Cloud {
+	authTypes := make([]cloud.AuthType, len(p.AuthTypes))
+	for i, authType := range p.AuthTypes {
+		authTypes[i] = cloud.AuthType(authType)
+	}
+	regions := make([]cloud.Region, len(p.Regions))
+	for i, region := range p.Regions {
+		regions[i] = cloud.Region{
+			Name:             region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+		}
+	}
+	return cloud.Cloud{
+		Name:             cloudName,
+		Type:             p.Type,
+		AuthTypes:        authTypes,
+		Endpoint:         p.Endpoint,
+		IdentityEndpoint: p.IdentityEndpoint,
+		StorageEndpoint:  p.StorageEndpoint,
+		Regions:          regions,
+	}
+}
+
 func cloudToParams(cloud cloud.Cloud) params.Cloud {
 	authTypes := make([]string, len(cloud.AuthTypes))
 	for i, authType := range cloud.AuthTypes {
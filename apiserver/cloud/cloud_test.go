@@ -85,6 +85,52 @@ func (s *cloudSuite) TestClouds(c *gc.C) {
 	})
 }
 
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	api, err := cloudfacade.NewCloudAPIV2(&s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.AddCloud(params.AddCloudArgs{
+		Name: "newcloud",
+		Cloud: params.Cloud{
+			Type:      "openstack",
+			AuthTypes: []string{"userpass"},
+			Endpoint:  "https://keystone.example.com",
+			Regions:   []params.CloudRegion{{Name: "one", Endpoint: "https://one.example.com"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "AddCloud")
+	s.backend.CheckCall(c, 1, "AddCloud", cloud.Cloud{
+		Name:      "newcloud",
+		Type:      "openstack",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+		Endpoint:  "https://keystone.example.com",
+		Regions:   []cloud.Region{{Name: "one", Endpoint: "https://one.example.com"}},
+	})
+}
+
+func (s *cloudSuite) TestAddCloudInvalidName(c *gc.C) {
+	api, err := cloudfacade.NewCloudAPIV2(&s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.AddCloud(params.AddCloudArgs{
+		Name:  "not/valid",
+		Cloud: params.Cloud{Type: "openstack", AuthTypes: []string{"userpass"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cloud name "not/valid" not valid`)
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *cloudSuite) TestAddCloudPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce")
+	api, err := cloudfacade.NewCloudAPIV2(&s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.AddCloud(params.AddCloudArgs{
+		Name:  "newcloud",
+		Cloud: params.Cloud{Type: "openstack", AuthTypes: []string{"userpass"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
 func (s *cloudSuite) TestDefaultCloud(c *gc.C) {
 	result, err := s.api.DefaultCloud()
 	c.Assert(err, jc.ErrorIsNil)
@@ -309,6 +355,11 @@ func (st *mockBackend) Cloud(name string) (cloud.Cloud, error) {
 	return st.cloud, st.NextErr()
 }
 
+func (st *mockBackend) AddCloud(cloud cloud.Cloud) error {
+	st.MethodCall(st, "AddCloud", cloud)
+	return st.NextErr()
+}
+
 func (st *mockBackend) Clouds() (map[names.CloudTag]cloud.Cloud, error) {
 	st.MethodCall(st, "Clouds")
 	return map[names.CloudTag]cloud.Cloud{
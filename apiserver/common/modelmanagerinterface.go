@@ -52,10 +52,15 @@ type ModelManagerBackend interface {
 	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
 	AllMachines() (machines []Machine, err error)
 	AllApplications() (applications []Application, err error)
+	PersistentStorageInstances() ([]state.StorageInstance, error)
 	ControllerUUID() string
 	ControllerTag() names.ControllerTag
 	Export() (description.Model, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access permission.Access) (permission.UserAccess, error)
+	SetModelUserExpiry(user names.UserTag, expires *time.Time) error
+	GroupAccess(group string, target names.Tag) (permission.Access, error)
+	SetGroupAccess(group string, target names.Tag, access permission.Access) error
+	SetApplicationAccess(user names.UserTag, appName string, access permission.Access) error
 	LastModelConnection(user names.UserTag) (time.Time, error)
 	LatestMigration() (state.ModelMigration, error)
 	DumpAll() (map[string]interface{}, error)
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// PasswordPolicy checks whether a new user password is acceptable.
+type PasswordPolicy interface {
+	// ValidatePassword returns an error satisfying errors.IsNotValid,
+	// describing what is wrong, if the password is not acceptable.
+	ValidatePassword(password string) error
+}
+
+// NewPasswordPolicy returns the PasswordPolicy described by the
+// password-min-length and password-min-character-classes controller
+// config attributes.
+func NewPasswordPolicy(cfg controller.Config) PasswordPolicy {
+	return configPasswordPolicy{
+		minLength:           cfg.PasswordMinLength(),
+		minCharacterClasses: cfg.PasswordMinCharacterClasses(),
+	}
+}
+
+type configPasswordPolicy struct {
+	minLength           int
+	minCharacterClasses int
+}
+
+// ValidatePassword is part of the PasswordPolicy interface.
+func (p configPasswordPolicy) ValidatePassword(password string) error {
+	if n := len([]rune(password)); n < p.minLength {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"password must be at least %d characters long, got %d", p.minLength, n,
+		))
+	}
+	if n := characterClasses(password); n < p.minCharacterClasses {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"password must contain at least %d of: lower case letters, upper case letters, digits, other characters",
+			p.minCharacterClasses,
+		))
+	}
+	return nil
+}
+
+// characterClasses returns how many of lower case letters, upper case
+// letters, digits and other characters appear in the password.
+func characterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
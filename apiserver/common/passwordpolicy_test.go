@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type passwordPolicySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&passwordPolicySuite{})
+
+func (s *passwordPolicySuite) TestNoPolicy(c *gc.C) {
+	policy := common.NewPasswordPolicy(controller.Config{})
+	c.Assert(policy.ValidatePassword("x"), jc.ErrorIsNil)
+}
+
+func (s *passwordPolicySuite) TestPolicy(c *gc.C) {
+	policy := common.NewPasswordPolicy(controller.Config{
+		controller.PasswordMinLength:           8,
+		controller.PasswordMinCharacterClasses: 3,
+	})
+	for i, test := range []struct {
+		password string
+		err      string
+	}{{
+		password: "Ab1!",
+		err:      "password must be at least 8 characters long, got 4",
+	}, {
+		password: "abcdefgh1",
+		err:      "password must contain at least 3 of: lower case letters, upper case letters, digits, other characters",
+	}, {
+		password: "abcdefgH1",
+	}, {
+		password: "ABCDEFG1!",
+	}} {
+		c.Logf("test %d: %q", i, test.password)
+		err := policy.ValidatePassword(test.password)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
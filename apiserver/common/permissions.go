@@ -22,7 +22,7 @@ const EveryoneTagName = "everyone@external"
 // and the host controller.
 func UserAccess(st *state.State, utag names.UserTag) (modelUser, controllerUser permission.UserAccess, err error) {
 	var none permission.UserAccess
-	modelUser, err = st.UserAccess(utag, st.ModelTag())
+	modelUser, err = UserAccessIncludingGroups(st)(utag, st.ModelTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return none, none, errors.Trace(err)
 	}
@@ -31,6 +31,10 @@ func UserAccess(st *state.State, utag names.UserTag) (modelUser, controllerUser
 	if err != nil && !errors.IsNotFound(err) {
 		return none, none, errors.Trace(err)
 	}
+	controllerUser, err = maybeUseUserGroupAccess(st, controllerUser, st.ControllerTag(), utag)
+	if err != nil {
+		return none, none, errors.Trace(err)
+	}
 
 	// TODO(perrito666) remove the following section about everyone group
 	// when groups are implemented, this accounts only for the lack of a local
@@ -144,6 +148,60 @@ func maybeUseGroupPermission(
 
 type userAccessFunc func(names.UserTag, names.Tag) (permission.UserAccess, error)
 
+// UserAccessIncludingGroups returns a function that reports the access
+// a user has on a model or the controller, raised to the greatest
+// access held by any group the user belongs to. It is suitable for
+// passing to HasPermission.
+func UserAccessIncludingGroups(st *state.State) func(names.UserTag, names.Tag) (permission.UserAccess, error) {
+	return func(userTag names.UserTag, target names.Tag) (permission.UserAccess, error) {
+		user, err := st.UserAccess(userTag, target)
+		if err != nil && !errors.IsNotFound(err) {
+			return permission.UserAccess{}, errors.Trace(err)
+		}
+		user, groupErr := maybeUseUserGroupAccess(st, user, target, userTag)
+		if groupErr != nil {
+			return permission.UserAccess{}, errors.Trace(groupErr)
+		}
+		if permission.IsEmptyUserAccess(user) {
+			return user, errors.Trace(err)
+		}
+		return user, nil
+	}
+}
+
+// maybeUseUserGroupAccess returns a permission.UserAccess updated with
+// the greatest access held on target by any group the user belongs to,
+// if that is higher than the user's own. If the user has no access of
+// their own but a group does, a stand-in is created to hold the group
+// access.
+func maybeUseUserGroupAccess(
+	st *state.State,
+	user permission.UserAccess,
+	target names.Tag,
+	userTag names.UserTag,
+) (permission.UserAccess, error) {
+	groupAccess, err := st.UserGroupAccess(userTag, target)
+	if err != nil {
+		return permission.UserAccess{}, errors.Annotate(err, "obtaining group access")
+	}
+	if groupAccess == permission.NoAccess {
+		return user, nil
+	}
+	if permission.IsEmptyUserAccess(user) {
+		user = permission.UserAccess{
+			UserID:   strings.ToLower(userTag.Id()),
+			UserTag:  userTag,
+			Object:   target,
+			UserName: userTag.Id(),
+		}
+	}
+	if target.Kind() == names.ModelTagKind && groupAccess.GreaterModelAccessThan(user.Access) ||
+		target.Kind() == names.ControllerTagKind && groupAccess.GreaterControllerAccessThan(user.Access) {
+		user.Access = groupAccess
+	}
+	return user, nil
+}
+
 // newControllerUserFromGroup returns a permission.UserAccess that serves
 // as a stand-in for a user that has group access but no explicit user
 // access.
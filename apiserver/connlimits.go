@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+const (
+	agentConnectionKind = "agent"
+	userConnectionKind  = "user"
+
+	rejectedLoginRateLimit = "login-rate-limit"
+	rejectedQuota          = "quota"
+
+	// userLoginRatePeriod is the period over which the per-user
+	// login rate limit is counted.
+	userLoginRatePeriod = time.Minute
+)
+
+// connectionMetrics holds the prometheus collectors updated
+// as logins are accepted and rejected by connectionLimits.
+type connectionMetrics struct {
+	connections *prometheus.GaugeVec
+	rejected    *prometheus.CounterVec
+}
+
+func newConnectionMetrics() *connectionMetrics {
+	return &connectionMetrics{
+		connections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "juju",
+			Subsystem: "api",
+			Name:      "logged_in_connections",
+			Help:      "Number of logged in API connections.",
+		}, []string{"kind"}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "juju",
+			Subsystem: "api",
+			Name:      "rejected_logins_total",
+			Help:      "Number of API logins rejected by rate limits or connection quotas.",
+		}, []string{"kind", "reason"}),
+	}
+}
+
+// register registers the metric collectors with the given registerer,
+// replacing any collectors registered by a previous API server.
+func (m *connectionMetrics) register(r prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{m.connections, m.rejected} {
+		r.Unregister(collector)
+		if err := r.Register(collector); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// connectionLimits enforces the maximum number of concurrent logged
+// in agent connections, and of concurrent connections and logins per
+// minute for any one user. A limit of zero means no limit.
+type connectionLimits struct {
+	maxAgentConnections int
+	maxUserConnections  int
+	userLoginRateLimit  int
+	clock               clock.Clock
+	metrics             *connectionMetrics
+
+	// mu guards the fields below it.
+	mu               sync.Mutex
+	agentConnections int
+	userConnections  map[string]int
+	userLogins       map[string][]time.Time
+}
+
+func newConnectionLimits(
+	maxAgentConnections, maxUserConnections, userLoginRateLimit int,
+	clock clock.Clock,
+	metrics *connectionMetrics,
+) *connectionLimits {
+	return &connectionLimits{
+		maxAgentConnections: maxAgentConnections,
+		maxUserConnections:  maxUserConnections,
+		userLoginRateLimit:  userLoginRateLimit,
+		clock:               clock,
+		metrics:             metrics,
+		userConnections:     make(map[string]int),
+		userLogins:          make(map[string][]time.Time),
+	}
+}
+
+// rejectLoginRateLimited records that an agent login was turned away
+// by the login rate limiter.
+func (l *connectionLimits) rejectLoginRateLimited() {
+	l.metrics.rejected.WithLabelValues(agentConnectionKind, rejectedLoginRateLimit).Inc()
+}
+
+// acquireAgent reserves a connection slot for an agent. It returns
+// common.ErrTryAgain if the agent connection quota has been reached.
+// The returned resource releases the slot when it is stopped.
+func (l *connectionLimits) acquireAgent(tag names.Tag) (*connectionSlot, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxAgentConnections > 0 && l.agentConnections >= l.maxAgentConnections {
+		logger.Debugf("agent connection quota (%d) reached, rejecting %s", l.maxAgentConnections, tag)
+		l.metrics.rejected.WithLabelValues(agentConnectionKind, rejectedQuota).Inc()
+		return nil, common.ErrTryAgain
+	}
+	l.agentConnections++
+	l.metrics.connections.WithLabelValues(agentConnectionKind).Inc()
+	return &connectionSlot{release: func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.agentConnections--
+		l.metrics.connections.WithLabelValues(agentConnectionKind).Dec()
+	}}, nil
+}
+
+// acquireUser reserves a connection slot for the given user. It
+// returns common.ErrTryAgain if the user already holds the maximum
+// number of connections, or has logged in too often in the last
+// minute, unless exempt is true; controller superusers are exempt so
+// that they can always reach a busy controller. The returned resource
+// releases the slot when it is stopped.
+func (l *connectionLimits) acquireUser(tag names.UserTag, exempt bool) (*connectionSlot, error) {
+	id := tag.Id()
+	now := l.clock.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	recent := recentLogins(l.userLogins[id], now)
+	if !exempt && l.userLoginRateLimit > 0 && len(recent) >= l.userLoginRateLimit {
+		logger.Debugf("user login rate limit (%d per %v) reached, rejecting %s", l.userLoginRateLimit, userLoginRatePeriod, tag)
+		l.metrics.rejected.WithLabelValues(userConnectionKind, rejectedLoginRateLimit).Inc()
+		l.userLogins[id] = recent
+		return nil, common.ErrTryAgain
+	}
+	if !exempt && l.maxUserConnections > 0 && l.userConnections[id] >= l.maxUserConnections {
+		logger.Debugf("user connection quota (%d) reached, rejecting %s", l.maxUserConnections, tag)
+		l.metrics.rejected.WithLabelValues(userConnectionKind, rejectedQuota).Inc()
+		return nil, common.ErrTryAgain
+	}
+	if l.userLoginRateLimit > 0 {
+		l.userLogins[id] = append(recent, now)
+	}
+	l.userConnections[id]++
+	l.metrics.connections.WithLabelValues(userConnectionKind).Inc()
+	return &connectionSlot{release: func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.userConnections[id]--; l.userConnections[id] <= 0 {
+			delete(l.userConnections, id)
+			if len(recentLogins(l.userLogins[id], l.clock.Now())) == 0 {
+				delete(l.userLogins, id)
+			}
+		}
+		l.metrics.connections.WithLabelValues(userConnectionKind).Dec()
+	}}, nil
+}
+
+// recentLogins returns those of the given login times, oldest first,
+// that fall within userLoginRatePeriod of now.
+func recentLogins(logins []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-userLoginRatePeriod)
+	for i, t := range logins {
+		if t.After(cutoff) {
+			return logins[i:]
+		}
+	}
+	return nil
+}
+
+// connectionSlot is a resource that releases a connection slot
+// held in connectionLimits when the connection is closed.
+type connectionSlot struct {
+	once    sync.Once
+	release func()
+}
+
+// Stop is part of the facade.Resource interface.
+func (s *connectionSlot) Stop() error {
+	s.once.Do(s.release)
+	return nil
+}
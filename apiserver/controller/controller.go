@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
@@ -33,6 +34,8 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+	// Version 4 adds AuditLog.
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPI)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -49,6 +52,7 @@ type Controller interface {
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+	AuditLog(params.AuditLogArgs) (params.AuditLogResult, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -364,6 +368,47 @@ func (c *ControllerAPI) GetControllerAccess(req params.Entities) (params.UserAcc
 	return results, nil
 }
 
+// AuditLog returns the entries of the controller's audit log that
+// match the arguments, newest first. Only controller superusers may
+// read the audit log.
+func (c *ControllerAPI) AuditLog(args params.AuditLogArgs) (params.AuditLogResult, error) {
+	var result params.AuditLogResult
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	filter := audit.Filter{Limit: args.Limit}
+	if args.UserTag != "" {
+		userTag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.OriginName = userTag.String()
+	}
+	if args.After != nil {
+		filter.After = args.After.UTC()
+	}
+	if args.Before != nil {
+		filter.Before = args.Before.UTC()
+	}
+	entries, err := c.state.AuditEntries(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			Timestamp:     entry.Timestamp,
+			ModelUUID:     entry.ModelUUID,
+			RemoteAddress: entry.RemoteAddress,
+			OriginType:    entry.OriginType,
+			OriginName:    entry.OriginName,
+			Operation:     entry.Operation,
+			Data:          entry.Data,
+		}
+	}
+	return result, nil
+}
+
 // InitiateMigration attempts to begin the migration of one or
 // more models to other controllers.
 func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs) (
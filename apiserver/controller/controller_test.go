@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -843,3 +845,55 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 		Message: "permission denied", Code: "unauthorized access",
 	})
 }
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	putAuditEntry := s.State.PutAuditEntryFn()
+	base := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, origin := range []string{"user-bob", "user-mary", "user-bob"} {
+		err := putAuditEntry(audit.AuditEntry{
+			JujuServerVersion: version.MustParse("2.2.0"),
+			ModelUUID:         s.State.ModelUUID(),
+			Timestamp:         base.Add(time.Duration(i) * time.Minute),
+			RemoteAddress:     "10.0.0.1:1234",
+			OriginType:        "API request",
+			OriginName:        origin,
+			Operation:         "Client:v1 - FullStatus",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	after := base.Add(30 * time.Second)
+	result, err := s.controller.AuditLog(params.AuditLogArgs{
+		UserTag: names.NewUserTag("bob").String(),
+		After:   &after,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Timestamp:     base.Add(2 * time.Minute),
+		ModelUUID:     s.State.ModelUUID(),
+		RemoteAddress: "10.0.0.1:1234",
+		OriginType:    "API request",
+		OriginName:    "user-bob",
+		Operation:     "Client:v1 - FullStatus",
+		Data:          map[string]interface{}{},
+	}})
+
+	result, err = s.controller.AuditLog(params.AuditLogArgs{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 2)
+	c.Check(result.Entries[0].OriginName, gc.Equals, "user-bob")
+	c.Check(result.Entries[1].OriginName, gc.Equals, "user-mary")
+}
+
+func (s *controllerSuite) TestAuditLogRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.AuditLog(params.AuditLogArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
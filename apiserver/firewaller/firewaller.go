@@ -20,6 +20,27 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	// Version 4 adds GetExposedPortRanges.
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPIV4)
+}
+
+// FirewallerAPIV4 provides access to version 4 of the Firewaller API
+// facade, which adds GetExposedPortRanges.
+type FirewallerAPIV4 struct {
+	*FirewallerAPI
+}
+
+// NewFirewallerAPIV4 creates a new server-side FirewallerAPIV4 facade.
+func NewFirewallerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FirewallerAPIV4, error) {
+	api, err := NewFirewallerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV4{api}, nil
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -287,6 +308,40 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedPortRanges returns, for each given application, the port
+// ranges of its exposed endpoints. An empty result means that all of
+// the application's opened ports are exposed, if it is exposed at all.
+func (f *FirewallerAPIV4) GetExposedPortRanges(args params.Entities) (params.PortRangesResults, error) {
+	result := params.PortRangesResults{
+		Results: make([]params.PortRangesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.PortRangesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		var portRanges []network.PortRange
+		for _, endpointRanges := range application.ExposedEndpoints() {
+			portRanges = append(portRanges, endpointRanges...)
+		}
+		network.SortPortRanges(portRanges)
+		for _, portRange := range portRanges {
+			result.Results[i].Result = append(result.Results[i].Result, params.FromNetworkPortRange(portRange))
+		}
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
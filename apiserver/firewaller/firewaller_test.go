@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -77,6 +78,50 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposedPortRanges(c *gc.C) {
+	firewallerV4, err := firewaller.NewFirewallerAPIV4(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.service.ExposeEndpoints(map[string][]network.PortRange{
+		"url": {
+			{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := firewallerV4.GetExposedPortRanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PortRangesResults{
+		Results: []params.PortRangesResult{
+			{Result: []params.PortRange{
+				{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Exposing the whole application clears the exposed endpoints.
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = firewallerV4.GetExposedPortRanges(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PortRangesResults{
+		Results: []params.PortRangesResult{{}},
+	})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
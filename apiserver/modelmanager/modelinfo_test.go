@@ -395,6 +395,11 @@ func (st *mockState) AllApplications() ([]common.Application, error) {
 	return nil, st.NextErr()
 }
 
+func (st *mockState) PersistentStorageInstances() ([]state.StorageInstance, error) {
+	st.MethodCall(st, "PersistentStorageInstances")
+	return nil, st.NextErr()
+}
+
 func (st *mockState) IsControllerAdmin(user names.UserTag) (bool, error) {
 	st.MethodCall(st, "IsControllerAdmin", user)
 	if st.controllerModel == nil {
@@ -549,6 +554,26 @@ func (st *mockState) SetUserAccess(subject names.UserTag, target names.Tag, acce
 	return permission.UserAccess{}, st.NextErr()
 }
 
+func (st *mockState) SetModelUserExpiry(user names.UserTag, expires *time.Time) error {
+	st.MethodCall(st, "SetModelUserExpiry", user, expires)
+	return st.NextErr()
+}
+
+func (st *mockState) GroupAccess(group string, target names.Tag) (permission.Access, error) {
+	st.MethodCall(st, "GroupAccess", group, target)
+	return permission.NoAccess, st.NextErr()
+}
+
+func (st *mockState) SetGroupAccess(group string, target names.Tag, access permission.Access) error {
+	st.MethodCall(st, "SetGroupAccess", group, target, access)
+	return st.NextErr()
+}
+
+func (st *mockState) SetApplicationAccess(user names.UserTag, appName string, access permission.Access) error {
+	st.MethodCall(st, "SetApplicationAccess", user, appName, access)
+	return st.NextErr()
+}
+
 func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
 	st.MethodCall(st, "ModelConfigDefaultValues")
 	return st.cfgDefaults, nil
@@ -37,7 +37,17 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
-	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+	common.RegisterStandardFacade("ModelManager", 2, newFacadeV4)
+	// Version 3 supports expiring model access grants.
+	common.RegisterStandardFacade("ModelManager", 3, newFacadeV4)
+	// Version 4 supports granting model access to groups.
+	common.RegisterStandardFacade("ModelManager", 4, newFacadeV4)
+	// Version 5 refuses to destroy models containing storage unless
+	// asked to destroy the storage too.
+	common.RegisterStandardFacade("ModelManager", 5, newFacade)
+	// Version 6 supports granting write access to individual
+	// applications in a model.
+	common.RegisterStandardFacade("ModelManager", 6, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -46,7 +56,7 @@ type ModelManager interface {
 	DumpModels(args params.Entities) params.MapResults
 	DumpModelsDB(args params.Entities) params.MapResults
 	ListModels(user params.Entity) (params.UserModelList, error)
-	DestroyModels(args params.Entities) (params.ErrorResults, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
 }
 
 // ModelManagerAPI implements the model manager interface and is
@@ -68,6 +78,20 @@ func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Mo
 	return NewModelManagerAPI(common.NewModelManagerBackend(st), configGetter, auth)
 }
 
+// ModelManagerAPIV4 provides versions 2 to 4 of the ModelManager
+// facade, which always destroy a model's storage along with it.
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPI
+}
+
+func newFacadeV4(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelManagerAPIV4, error) {
+	api, err := newFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV4{api}, nil
+}
+
 // NewModelManagerAPI creates a new api server endpoint for managing
 // models.
 func NewModelManagerAPI(
@@ -490,12 +514,18 @@ func (m *ModelManagerAPI) ListModels(user params.Entity) (params.UserModelList,
 
 // DestroyModels will try to destroy the specified models.
 // If there is a block on destruction, this method will return an error.
-func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResults, error) {
+// Models that contain storage are only destroyed if DestroyStorage
+// is set.
+func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
-		Results: make([]params.ErrorResult, len(args.Entities)),
+		Results: make([]params.ErrorResult, len(args.Models)),
 	}
 
-	destroyModel := func(tag names.ModelTag) error {
+	destroyModel := func(arg params.DestroyModelParams) error {
+		tag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		model, err := m.state.GetModel(tag)
 		if err != nil {
 			return errors.Trace(err)
@@ -503,23 +533,61 @@ func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResul
 		if err := m.authCheck(model.Owner()); err != nil {
 			return errors.Trace(err)
 		}
+		if !arg.DestroyStorage {
+			if err := m.checkNoStorage(model.ModelTag()); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		return errors.Trace(common.DestroyModel(m.state, model.ModelTag()))
 	}
 
-	for i, arg := range args.Entities {
-		tag, err := names.ParseModelTag(arg.Tag)
-		if err != nil {
+	for i, arg := range args.Models {
+		if err := destroyModel(arg); err != nil {
 			results.Results[i].Error = common.ServerError(err)
-			continue
-		}
-		if err := destroyModel(tag); err != nil {
-			results.Results[i].Error = common.ServerError(err)
-			continue
 		}
 	}
 	return results, nil
 }
 
+// checkNoStorage returns an error with the CodeHasPersistentStorage
+// code if the model contains any persistent storage instances. Storage
+// bound to a machine, such as rootfs or tmpfs, is destroyed along with
+// the machine regardless, so it is not counted.
+func (m *ModelManagerAPI) checkNoStorage(modelTag names.ModelTag) error {
+	st, err := m.state.ForModel(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	storage, err := st.PersistentStorageInstances()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(storage) > 0 {
+		return &params.Error{
+			Code:    params.CodeHasPersistentStorage,
+			Message: fmt.Sprintf("model contains %d persistent storage instance(s)", len(storage)),
+		}
+	}
+	return nil
+}
+
+// DestroyModels will try to destroy the specified models, along with
+// any storage they contain.
+func (m *ModelManagerAPIV4) DestroyModels(args params.Entities) (params.ErrorResults, error) {
+	destroyArgs := params.DestroyModelsParams{
+		Models: make([]params.DestroyModelParams, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		destroyArgs.Models[i] = params.DestroyModelParams{
+			ModelTag:       arg.Tag,
+			DestroyStorage: true,
+		}
+	}
+	return m.ModelManagerAPI.DestroyModels(destroyArgs)
+}
+
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
@@ -689,6 +757,35 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 			continue
 		}
 
+		if len(arg.Applications) > 0 {
+			if arg.Group != "" {
+				result.Results[i].Error = common.ServerError(errors.NotSupportedf("application access for groups"))
+				continue
+			}
+			if arg.Expires != nil {
+				result.Results[i].Error = common.ServerError(errors.NotSupportedf("expiring application access"))
+				continue
+			}
+			targetUserTag, err := names.ParseUserTag(arg.UserTag)
+			if err != nil {
+				result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify application access"))
+				continue
+			}
+			result.Results[i].Error = common.ServerError(
+				changeApplicationAccess(m.state, modelTag, m.apiUser, targetUserTag, arg.Action, modelAccess, arg.Applications, m.isAdmin))
+			continue
+		}
+
+		if arg.Group != "" {
+			if arg.Expires != nil {
+				result.Results[i].Error = common.ServerError(errors.NotSupportedf("expiring group access"))
+				continue
+			}
+			result.Results[i].Error = common.ServerError(
+				changeGroupModelAccess(m.state, modelTag, m.apiUser, arg.Group, arg.Action, modelAccess, m.isAdmin))
+			continue
+		}
+
 		targetUserTag, err := names.ParseUserTag(arg.UserTag)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify model access"))
@@ -696,7 +793,7 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 		}
 
 		result.Results[i].Error = common.ServerError(
-			changeModelAccess(m.state, modelTag, m.apiUser, targetUserTag, arg.Action, modelAccess, m.isAdmin))
+			changeModelAccess(m.state, modelTag, m.apiUser, targetUserTag, arg.Action, modelAccess, arg.Expires, m.isAdmin))
 	}
 	return result, nil
 }
@@ -728,8 +825,9 @@ func userAuthorizedToChangeAccess(st common.ModelManagerBackend, userIsAdmin boo
 }
 
 // changeModelAccess performs the requested access grant or revoke action for the
-// specified user on the specified model.
-func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser, targetUserTag names.UserTag, action params.ModelAction, access permission.Access, userIsAdmin bool) error {
+// specified user on the specified model. A grant with a non-nil expiry is
+// revoked automatically once it lapses.
+func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser, targetUserTag names.UserTag, action params.ModelAction, access permission.Access, expires *time.Time, userIsAdmin bool) error {
 	st, err := accessor.ForModel(modelTag)
 	if err != nil {
 		return errors.Annotate(err, "could not lookup model")
@@ -740,9 +838,18 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 		return errors.Trace(err)
 	}
 
+	if expires != nil && action != params.GrantModelAccess {
+		return errors.NotValidf("expiry on %q", action)
+	}
+
 	switch action {
 	case params.GrantModelAccess:
-		_, err = st.AddModelUser(modelTag.Id(), state.UserAccessSpec{User: targetUserTag, CreatedBy: apiUser, Access: access})
+		_, err = st.AddModelUser(modelTag.Id(), state.UserAccessSpec{
+			User:      targetUserTag,
+			CreatedBy: apiUser,
+			Access:    access,
+			Expires:   expires,
+		})
 		if errors.IsAlreadyExists(err) {
 			modelUser, err := st.UserAccess(targetUserTag, modelTag)
 			if errors.IsNotFound(err) {
@@ -760,6 +867,12 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 			if _, err = st.SetUserAccess(modelUser.UserTag, modelUser.Object, access); err != nil {
 				return errors.Annotate(err, "could not set model access for user")
 			}
+			// The new grant replaces any expiry on the old one.
+			if expires != nil || modelUser.Expires != nil {
+				if err := st.SetModelUserExpiry(modelUser.UserTag, expires); err != nil {
+					return errors.Annotate(err, "could not set model access expiry for user")
+				}
+			}
 			return nil
 		}
 		return errors.Annotate(err, "could not grant model access")
@@ -796,6 +909,108 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 	}
 }
 
+// changeApplicationAccess performs the requested grant or revoke of
+// write access for the specified user on each of the named applications
+// in the specified model. Granting application access gives the user
+// read access to the model if they have none.
+func changeApplicationAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser, targetUserTag names.UserTag, action params.ModelAction, access permission.Access, appNames []string, userIsAdmin bool) error {
+	if access != permission.WriteAccess {
+		return errors.NotValidf("application access %q", access)
+	}
+	for _, appName := range appNames {
+		if !names.IsValidApplication(appName) {
+			return errors.NotValidf("application name %q", appName)
+		}
+	}
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	if err := userAuthorizedToChangeAccess(st, userIsAdmin, apiUser); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch action {
+	case params.GrantModelAccess:
+		_, err := st.UserAccess(targetUserTag, modelTag)
+		if errors.IsNotFound(err) {
+			_, err = st.AddModelUser(modelTag.Id(), state.UserAccessSpec{
+				User:      targetUserTag,
+				CreatedBy: apiUser,
+				Access:    permission.ReadAccess,
+			})
+		}
+		if err != nil {
+			return errors.Annotate(err, "could not grant model access")
+		}
+	case params.RevokeModelAccess:
+		access = permission.NoAccess
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+	for _, appName := range appNames {
+		if err := st.SetApplicationAccess(targetUserTag, appName, access); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// changeGroupModelAccess performs the requested access grant or revoke
+// action for the specified group on the specified model. Revoking
+// lowers the group's access by one level, as for users.
+func changeGroupModelAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser names.UserTag, group string, action params.ModelAction, access permission.Access, userIsAdmin bool) error {
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	if err := userAuthorizedToChangeAccess(st, userIsAdmin, apiUser); err != nil {
+		return errors.Trace(err)
+	}
+
+	current, err := st.GroupAccess(group, modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not look up model access for group")
+	}
+	switch action {
+	case params.GrantModelAccess:
+		if current.EqualOrGreaterModelAccessThan(access) {
+			return errors.Errorf("group already has %q access or greater", access)
+		}
+		err := st.SetGroupAccess(group, modelTag, access)
+		return errors.Annotate(err, "could not grant model access")
+
+	case params.RevokeModelAccess:
+		if current == permission.NoAccess {
+			return errors.NotFoundf("model access for group %q", group)
+		}
+		var newAccess permission.Access
+		switch access {
+		case permission.ReadAccess:
+			newAccess = permission.NoAccess
+		case permission.WriteAccess:
+			newAccess = permission.ReadAccess
+		case permission.AdminAccess:
+			newAccess = permission.WriteAccess
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+		if !current.GreaterModelAccessThan(newAccess) {
+			// The group's access is already below the revoked level.
+			return nil
+		}
+		err := st.SetGroupAccess(group, modelTag, newAccess)
+		return errors.Annotate(err, "could not revoke model access")
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 // ModelDefaults returns the default config values used when creating a new model.
 func (m *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
 	result := params.ModelDefaultsResult{}
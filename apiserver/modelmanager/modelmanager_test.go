@@ -919,8 +919,8 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
-	results, err := s.modelmanager.DestroyModels(params.Entities{
-		Entities: []params.Entity{{"model-" + m.UUID}},
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{ModelTag: "model-" + m.UUID}},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
@@ -950,8 +950,8 @@ func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 	other := s.AdminUserTag(c)
 	s.setAPIUser(c, other)
 
-	results, err := s.modelmanager.DestroyModels(params.Entities{
-		Entities: []params.Entity{{"model-" + m.UUID}},
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{ModelTag: "model-" + m.UUID}},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
@@ -980,11 +980,11 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 	user := names.NewUserTag("other@remote")
 	s.setAPIUser(c, user)
 
-	results, err := s.modelmanager.DestroyModels(params.Entities{
-		Entities: []params.Entity{
-			{"model-" + m.UUID},
-			{"model-9f484882-2f18-4fd2-967d-db9663db7bea"},
-			{"machine-42"},
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{
+			{ModelTag: "model-" + m.UUID},
+			{ModelTag: "model-9f484882-2f18-4fd2-967d-db9663db7bea"},
+			{ModelTag: "machine-42"},
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -1011,6 +1011,124 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 	c.Assert(model.Life(), gc.Equals, state.Alive)
 }
 
+func (s *modelManagerStateSuite) TestDestroyModelWithStorage(c *gc.C) {
+	owner := names.NewUserTag("admin")
+	s.setAPIUser(c, owner)
+	m, err := s.modelmanager.CreateModel(createArgs(owner))
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.State.ForModel(names.NewModelTag(m.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	f := factory.NewFactory(st)
+	f.MakeUnit(c, &factory.UnitParams{
+		Application: f.MakeApplication(c, &factory.ApplicationParams{
+			Charm: f.MakeCharm(c, &factory.CharmParams{Name: "storage-block"}),
+			Storage: map[string]state.StorageConstraints{
+				"data": {Pool: "modelscoped-block", Count: 1, Size: 1024},
+			},
+		}),
+	})
+
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(
+		common.NewModelManagerBackend(st), nil, s.authoriser,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{ModelTag: "model-" + m.UUID}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		&params.Error{
+			Message: "model contains 1 persistent storage instance(s)",
+			Code:    params.CodeHasPersistentStorage,
+		},
+	}})
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Equals, state.Alive)
+
+	results, err = s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{ModelTag: "model-" + m.UUID, DestroyStorage: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+}
+
+func (s *modelManagerStateSuite) TestDestroyModelWithMachineStorage(c *gc.C) {
+	owner := names.NewUserTag("admin")
+	s.setAPIUser(c, owner)
+	m, err := s.modelmanager.CreateModel(createArgs(owner))
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.State.ForModel(names.NewModelTag(m.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// Loop devices are bound to their machine, so they do not stop
+	// the model from being destroyed.
+	f := factory.NewFactory(st)
+	f.MakeUnit(c, &factory.UnitParams{
+		Application: f.MakeApplication(c, &factory.ApplicationParams{
+			Charm: f.MakeCharm(c, &factory.CharmParams{Name: "storage-block"}),
+			Storage: map[string]state.StorageConstraints{
+				"data": {Pool: "loop", Count: 1, Size: 1024},
+			},
+		}),
+	})
+
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(
+		common.NewModelManagerBackend(st), nil, s.authoriser,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{ModelTag: "model-" + m.UUID}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+}
+
+func (s *modelManagerStateSuite) TestDestroyModelsV4DestroysStorage(c *gc.C) {
+	owner := names.NewUserTag("admin")
+	s.setAPIUser(c, owner)
+	m, err := s.modelmanager.CreateModel(createArgs(owner))
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.State.ForModel(names.NewModelTag(m.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	f := factory.NewFactory(st)
+	f.MakeUnit(c, &factory.UnitParams{
+		Application: f.MakeApplication(c, &factory.ApplicationParams{
+			Charm: f.MakeCharm(c, &factory.CharmParams{Name: "storage-block"}),
+			Storage: map[string]state.StorageConstraints{
+				"data": {Pool: "loop", Count: 1, Size: 1024},
+			},
+		}),
+	})
+
+	api, err := modelmanager.NewModelManagerAPI(
+		common.NewModelManagerBackend(st), nil, s.authoriser,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	apiV4 := &modelmanager.ModelManagerAPIV4{api}
+
+	results, err := apiV4.DestroyModels(params.Entities{
+		Entities: []params.Entity{{"model-" + m.UUID}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+}
+
 func (s *modelManagerStateSuite) modifyAccess(c *gc.C, user names.UserTag, action params.ModelAction, access params.UserAccessPermission, model names.ModelTag) error {
 	args := params.ModifyModelAccessRequest{
 		Changes: []params.ModifyModelAccess{{
@@ -1187,6 +1305,257 @@ func (s *modelManagerStateSuite) TestGrantModelIncreaseAccess(c *gc.C) {
 	c.Assert(modelUser.Access, gc.Equals, permission.WriteAccess)
 }
 
+func (s *modelManagerStateSuite) modifyAccessWithExpiry(c *gc.C, user names.UserTag, action params.ModelAction, access params.UserAccessPermission, model names.ModelTag, expires time.Time) error {
+	args := params.ModifyModelAccessRequest{
+		Changes: []params.ModifyModelAccess{{
+			UserTag:  user.String(),
+			Action:   action,
+			Access:   access,
+			ModelTag: model.String(),
+			Expires:  &expires,
+		}}}
+
+	result, err := s.modelmanager.ModifyModelAccess(args)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+func (s *modelManagerStateSuite) TestGrantModelWithExpiry(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := s.modifyAccessWithExpiry(c, user.UserTag(), params.GrantModelAccess, params.ModelReadAccess, st.ModelTag(), expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := st.UserAccess(user.UserTag(), st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
+	c.Assert(modelUser.Expires, gc.NotNil)
+	c.Assert(modelUser.Expires.Equal(expires), jc.IsTrue)
+}
+
+func (s *modelManagerStateSuite) TestGrantModelIncreaseAccessReplacesExpiry(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := s.modifyAccessWithExpiry(c, user.UserTag(), params.GrantModelAccess, params.ModelReadAccess, st.ModelTag(), expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.grant(c, user.UserTag(), params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := st.UserAccess(user.UserTag(), st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.WriteAccess)
+	c.Assert(modelUser.Expires, gc.IsNil)
+}
+
+func (s *modelManagerStateSuite) TestRevokeWithExpiryFails(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, nil)
+
+	err := s.modifyAccessWithExpiry(c, user.UserTag, params.RevokeModelAccess, params.ModelReadAccess, user.Object.(names.ModelTag), time.Now())
+	c.Assert(err, gc.ErrorMatches, `expiry on "revoke" not valid`)
+}
+
+func (s *modelManagerStateSuite) modifyGroupAccess(c *gc.C, group string, action params.ModelAction, access params.UserAccessPermission, model names.ModelTag) error {
+	args := params.ModifyModelAccessRequest{
+		Changes: []params.ModifyModelAccess{{
+			Group:    group,
+			Action:   action,
+			Access:   access,
+			ModelTag: model.String(),
+		}}}
+
+	result, err := s.modelmanager.ModifyModelAccess(args)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+func (s *modelManagerStateSuite) TestGrantGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	_, err := s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyGroupAccess(c, "devs", params.GrantModelAccess, params.ModelWriteAccess, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyGroupAccess(c, "devs", params.GrantModelAccess, params.ModelReadAccess, s.State.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `group already has "read" access or greater`)
+}
+
+func (s *modelManagerStateSuite) TestGrantMissingGroupFails(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	err := s.modifyGroupAccess(c, "devs", params.GrantModelAccess, params.ModelReadAccess, s.State.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `could not grant model access: setting access for group "devs": group "devs" not found`)
+}
+
+func (s *modelManagerStateSuite) TestRevokeGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	_, err := s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyGroupAccess(c, "devs", params.RevokeModelAccess, params.ModelAdminAccess, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyGroupAccess(c, "devs", params.RevokeModelAccess, params.ModelReadAccess, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+
+	err = s.modifyGroupAccess(c, "devs", params.RevokeModelAccess, params.ModelReadAccess, s.State.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `model access for group "devs" not found`)
+}
+
+func (s *modelManagerStateSuite) TestGrantGroupModelAdmin(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	_, err := s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	apiUser := names.NewUserTag("admin@remote")
+	factory.NewFactory(st).MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Id(), Access: permission.AdminAccess})
+	s.setAPIUser(c, apiUser)
+
+	err = s.modifyGroupAccess(c, "devs", params.GrantModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := st.GroupAccess("devs", st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+}
+
+func (s *modelManagerStateSuite) TestGrantGroupModelWriteAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	_, err := s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	apiUser := names.NewUserTag("bob@remote")
+	factory.NewFactory(st).MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Id(), Access: permission.WriteAccess})
+	s.setAPIUser(c, apiUser)
+
+	err = s.modifyGroupAccess(c, "devs", params.GrantModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	access, err := st.GroupAccess("devs", st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *modelManagerStateSuite) modifyApplicationAccess(c *gc.C, user names.UserTag, action params.ModelAction, access params.UserAccessPermission, model names.ModelTag, apps ...string) error {
+	args := params.ModifyModelAccessRequest{
+		Changes: []params.ModifyModelAccess{{
+			UserTag:      user.String(),
+			Action:       action,
+			Access:       access,
+			ModelTag:     model.String(),
+			Applications: apps,
+		}}}
+
+	result, err := s.modelmanager.ModifyModelAccess(args)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyApplicationAccess(c, user.UserTag(), params.GrantModelAccess, params.ModelWriteAccess, s.State.ModelTag(), "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ApplicationAccess(user.UserTag(), "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+	modelUser, err := s.State.UserAccess(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
+
+	err = s.modifyApplicationAccess(c, user.UserTag(), params.RevokeModelAccess, params.ModelWriteAccess, s.State.ModelTag(), "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ApplicationAccess(user.UserTag(), "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationAccessOnlyWrite(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, nil)
+	err := s.modifyApplicationAccess(c, user.UserTag, params.GrantModelAccess, params.ModelAdminAccess, s.State.ModelTag(), "wordpress")
+	c.Assert(err, gc.ErrorMatches, `application access "admin" not valid`)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationAccessMissingApplication(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, nil)
+	err := s.modifyApplicationAccess(c, user.UserTag, params.GrantModelAccess, params.ModelWriteAccess, s.State.ModelTag(), "wordpress")
+	c.Assert(err, gc.ErrorMatches, `setting access to application "wordpress" for user ".*": application "wordpress" not found`)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationAccessModelAdmin(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	stFactory := factory.NewFactory(st)
+	stFactory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	apiUser := names.NewUserTag("admin@remote")
+	stFactory.MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Id(), Access: permission.AdminAccess})
+	s.setAPIUser(c, apiUser)
+
+	other := names.NewUserTag("other@remote")
+	err := s.modifyApplicationAccess(c, other, params.GrantModelAccess, params.ModelWriteAccess, st.ModelTag(), "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := st.ApplicationAccess(other, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationAccessModelWriteAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	stFactory := factory.NewFactory(st)
+	stFactory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	apiUser := names.NewUserTag("bob@remote")
+	stFactory.MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Id(), Access: permission.WriteAccess})
+	s.setAPIUser(c, apiUser)
+
+	other := names.NewUserTag("other@remote")
+	err := s.modifyApplicationAccess(c, other, params.GrantModelAccess, params.ModelWriteAccess, st.ModelTag(), "wordpress")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	access, err := st.ApplicationAccess(other, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
 func (s *modelManagerStateSuite) TestGrantToModelNoAccess(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	st := s.Factory.MakeModel(c, nil)
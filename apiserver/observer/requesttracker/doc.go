@@ -0,0 +1,8 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package requesttracker provides an implementation of
+// apiserver/observer.ObserverFactory that keeps track of the API
+// requests in flight, and logs requests that take longer than a
+// configured threshold.
+package requesttracker
@@ -0,0 +1,285 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package requesttracker
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+)
+
+// Config contains the configuration for a Tracker.
+type Config struct {
+	// Clock is the clock to use for all time-related operations.
+	Clock clock.Clock
+
+	// Logger is the logger to which slow requests are written.
+	Logger loggo.Logger
+
+	// SlowRequestThreshold is the duration after which a request is
+	// considered slow and logged as a warning when it completes. If
+	// it is zero, slow requests are not logged.
+	SlowRequestThreshold time.Duration
+}
+
+// Validate validates the tracker configuration.
+func (cfg Config) Validate() error {
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if cfg.SlowRequestThreshold < 0 {
+		return errors.NotValidf("negative SlowRequestThreshold")
+	}
+	return nil
+}
+
+// Request describes an API request being served.
+type Request struct {
+	// ConnectionID identifies the API connection the request was
+	// made on.
+	ConnectionID uint64
+
+	// Entity holds the tag of the entity logged in on the
+	// connection, or an empty string if there is none yet.
+	Entity string
+
+	// Facade, Version and Method identify the method called.
+	Facade  string
+	Version int
+	Method  string
+
+	// Entities holds the number of items in a bulk request.
+	Entities int
+
+	// Started holds the time at which the request was received.
+	Started time.Time
+}
+
+func (r Request) String() string {
+	entity := r.Entity
+	if entity == "" {
+		entity = "(not logged in)"
+	}
+	return fmt.Sprintf("[%X] %s %s(%d).%s entities=%d",
+		r.ConnectionID, entity, r.Facade, r.Version, r.Method, r.Entities)
+}
+
+// Tracker keeps track of the API requests in flight across all
+// API connections.
+type Tracker struct {
+	config Config
+
+	mu       sync.Mutex
+	lastID   uint64
+	inFlight map[uint64]Request
+}
+
+// NewTracker returns a new Tracker with the given configuration.
+func NewTracker(config Config) (*Tracker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	return &Tracker{
+		config:   config,
+		inFlight: make(map[uint64]Request),
+	}, nil
+}
+
+// NewObserver returns an Observer for a single API connection. It
+// has the signature of observer.ObserverFactory.
+func (t *Tracker) NewObserver() observer.Observer {
+	return &Observer{tracker: t}
+}
+
+// InFlight returns the requests currently being served, oldest first.
+func (t *Tracker) InFlight() []Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]uint64, 0, len(t.inFlight))
+	for id := range t.inFlight {
+		ids = append(ids, id)
+	}
+	sort.Sort(uint64s(ids))
+	requests := make([]Request, len(ids))
+	for i, id := range ids {
+		requests[i] = t.inFlight[id]
+	}
+	return requests
+}
+
+// IntrospectionReport is used by the introspection worker to report
+// the requests in flight.
+func (t *Tracker) IntrospectionReport() string {
+	requests := t.InFlight()
+	now := t.config.Clock.Now()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "API requests in flight: %d\n", len(requests))
+	if len(requests) > 0 {
+		fmt.Fprintln(&buf)
+	}
+	for _, r := range requests {
+		fmt.Fprintf(&buf, "%s running for %v\n", r, now.Sub(r.Started))
+	}
+	return buf.String()
+}
+
+func (t *Tracker) start(r Request) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastID++
+	t.inFlight[t.lastID] = r
+	return t.lastID
+}
+
+// abandon stops tracking the given requests without reporting their
+// duration. It is used for requests that will never be replied to.
+func (t *Tracker) abandon(ids []uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range ids {
+		delete(t.inFlight, id)
+	}
+}
+
+func (t *Tracker) finish(id uint64) {
+	t.mu.Lock()
+	r, ok := t.inFlight[id]
+	delete(t.inFlight, id)
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	duration := t.config.Clock.Now().Sub(r.Started)
+	if t.config.SlowRequestThreshold > 0 && duration >= t.config.SlowRequestThreshold {
+		t.config.Logger.Warningf("slow API request %s took %v", r, duration)
+	} else {
+		t.config.Logger.Tracef("API request %s took %v", r, duration)
+	}
+}
+
+// Observer is an API server connection observer that reports the
+// requests made on the connection to its Tracker.
+type Observer struct {
+	tracker *Tracker
+
+	mu           sync.Mutex
+	connectionID uint64
+	entity       string
+
+	// pending holds the tracker ids of the requests on the connection
+	// that have not been replied to.
+	pending map[uint64]bool
+}
+
+// Login is part of the observer.Observer interface.
+func (o *Observer) Login(entity names.Tag, _ names.ModelTag, _ bool, _ string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entity = entity.String()
+}
+
+// Join is part of the observer.Observer interface.
+func (o *Observer) Join(req *http.Request, connectionID uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.connectionID = connectionID
+}
+
+// Leave is part of the observer.Observer interface. Requests on the
+// connection that were never replied to, such as those whose body
+// could not be read before the connection closed, stop being tracked.
+func (o *Observer) Leave() {
+	o.mu.Lock()
+	ids := make([]uint64, 0, len(o.pending))
+	for id := range o.pending {
+		ids = append(ids, id)
+	}
+	o.pending = nil
+	o.mu.Unlock()
+	o.tracker.abandon(ids)
+}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *Observer) RPCObserver() rpc.Observer {
+	return &rpcObserver{connection: o}
+}
+
+type rpcObserver struct {
+	connection *Observer
+	id         uint64
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	o.connection.mu.Lock()
+	r := Request{
+		ConnectionID: o.connection.connectionID,
+		Entity:       o.connection.entity,
+	}
+	o.connection.mu.Unlock()
+	r.Facade = hdr.Request.Type
+	r.Version = hdr.Request.Version
+	r.Method = hdr.Request.Action
+	r.Entities = entityCount(body)
+	r.Started = o.connection.tracker.config.Clock.Now()
+	o.id = o.connection.tracker.start(r)
+
+	o.connection.mu.Lock()
+	defer o.connection.mu.Unlock()
+	if o.connection.pending == nil {
+		o.connection.pending = make(map[uint64]bool)
+	}
+	o.connection.pending[o.id] = true
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	o.connection.mu.Lock()
+	delete(o.connection.pending, o.id)
+	o.connection.mu.Unlock()
+	o.connection.tracker.finish(o.id)
+}
+
+// entityCount returns the number of items in a request body. Bulk
+// request parameters are structs whose first slice field holds the
+// items, such as params.Entities.
+func entityCount(body interface{}) int {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Len()
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.Kind() == reflect.Slice {
+				return f.Len()
+			}
+		}
+	}
+	return 0
+}
+
+type uint64s []uint64
+
+func (s uint64s) Len() int           { return len(s) }
+func (s uint64s) Less(i, j int) bool { return s[i] < s[j] }
+func (s uint64s) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
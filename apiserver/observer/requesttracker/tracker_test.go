@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package requesttracker_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer/requesttracker"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type trackerSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	tracker *requesttracker.Tracker
+}
+
+var _ = gc.Suite(&trackerSuite{})
+
+func (s *trackerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+
+	var err error
+	s.tracker, err = requesttracker.NewTracker(requesttracker.Config{
+		Clock:                s.clock,
+		Logger:               loggo.GetLogger("requesttracker.test"),
+		SlowRequestThreshold: 5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *trackerSuite) TestValidate(c *gc.C) {
+	_, err := requesttracker.NewTracker(requesttracker.Config{})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Clock not valid")
+	_, err = requesttracker.NewTracker(requesttracker.Config{
+		Clock:                s.clock,
+		SlowRequestThreshold: -time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "validating config: negative SlowRequestThreshold not valid")
+}
+
+func (s *trackerSuite) serverRequest(c *gc.C, body interface{}) rpc.Observer {
+	o := s.tracker.NewObserver()
+	o.Join(&http.Request{}, 42)
+	o.Login(names.NewMachineTag("0"), coretesting.ModelTag, false, "")
+	rpcObserver := o.RPCObserver()
+	rpcObserver.ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: "Uniter", Version: 4, Action: "Life"},
+	}, body)
+	return rpcObserver
+}
+
+func (s *trackerSuite) TestInFlight(c *gc.C) {
+	c.Assert(s.tracker.InFlight(), gc.HasLen, 0)
+
+	body := params.Entities{Entities: []params.Entity{{Tag: "unit-a-0"}, {Tag: "unit-a-1"}}}
+	rpcObserver := s.serverRequest(c, body)
+	c.Assert(s.tracker.InFlight(), jc.DeepEquals, []requesttracker.Request{{
+		ConnectionID: 42,
+		Entity:       "machine-0",
+		Facade:       "Uniter",
+		Version:      4,
+		Method:       "Life",
+		Entities:     2,
+		Started:      s.clock.Now(),
+	}})
+
+	s.clock.Advance(time.Second)
+	c.Assert(s.tracker.IntrospectionReport(), gc.Equals, `
+API requests in flight: 1
+
+[2A] machine-0 Uniter(4).Life entities=2 running for 1s
+`[1:])
+
+	rpcObserver.ServerReply(rpc.Request{}, &rpc.Header{}, struct{}{})
+	c.Assert(s.tracker.InFlight(), gc.HasLen, 0)
+	c.Assert(s.tracker.IntrospectionReport(), gc.Equals, "API requests in flight: 0\n")
+}
+
+func (s *trackerSuite) TestInFlightNoBody(c *gc.C) {
+	s.serverRequest(c, nil)
+	requests := s.tracker.InFlight()
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Entities, gc.Equals, 0)
+}
+
+func (s *trackerSuite) TestLeaveAbandonsPendingRequests(c *gc.C) {
+	o := s.tracker.NewObserver()
+	o.Join(&http.Request{}, 42)
+	replied := o.RPCObserver()
+	replied.ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: "Uniter", Version: 4, Action: "Life"},
+	}, nil)
+	replied.ServerReply(rpc.Request{}, &rpc.Header{}, struct{}{})
+
+	// A request whose body hits EOF is never replied to.
+	o.RPCObserver().ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: "Uniter", Version: 4, Action: "Watch"},
+	}, nil)
+	other := s.serverRequest(c, nil)
+	c.Assert(s.tracker.InFlight(), gc.HasLen, 2)
+
+	// Only the requests on the closed connection are abandoned.
+	o.Leave()
+	requests := s.tracker.InFlight()
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Method, gc.Equals, "Life")
+
+	other.ServerReply(rpc.Request{}, &rpc.Header{}, struct{}{})
+	c.Assert(s.tracker.InFlight(), gc.HasLen, 0)
+}
+
+func (s *trackerSuite) TestSlowRequestLogged(c *gc.C) {
+	var logWriter loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("requesttracker-tests", &logWriter), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("requesttracker-tests")
+
+	rpcObserver := s.serverRequest(c, params.Entities{})
+	s.clock.Advance(time.Second)
+	rpcObserver.ServerReply(rpc.Request{}, &rpc.Header{}, struct{}{})
+
+	rpcObserver = s.serverRequest(c, params.Entities{})
+	s.clock.Advance(5 * time.Second)
+	rpcObserver.ServerReply(rpc.Request{}, &rpc.Header{}, struct{}{})
+
+	var warnings []string
+	for _, entry := range logWriter.Log() {
+		if entry.Level == loggo.WARNING {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+	c.Assert(warnings, jc.DeepEquals, []string{
+		"slow API request [2A] machine-0 Uniter(4).Life entities=0 took 5s",
+	})
+}
@@ -94,6 +94,7 @@ const (
 	CodeDischargeRequired         = "macaroon discharge required"
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
+	CodeHasPersistentStorage      = "has persistent storage"
 )
 
 // ErrCode returns the error code associated with
@@ -188,6 +189,10 @@ func IsCodeMachineHasAttachedStorage(err error) bool {
 	return ErrCode(err) == CodeMachineHasAttachedStorage
 }
 
+func IsCodeHasPersistentStorage(err error) bool {
+	return ErrCode(err) == CodeHasPersistentStorage
+}
+
 func IsCodeNotProvisioned(err error) bool {
 	return ErrCode(err) == CodeNotProvisioned
 }
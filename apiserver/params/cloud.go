@@ -32,6 +32,12 @@ type CloudResults struct {
 	Results []CloudResult `json:"results,omitempty"`
 }
 
+// AddCloudArgs holds a cloud definition to be added to the controller.
+type AddCloudArgs struct {
+	Cloud Cloud  `json:"cloud"`
+	Name  string `json:"name"`
+}
+
 // CloudsResult contains a set of Clouds.
 type CloudsResult struct {
 	// Clouds is a map of clouds, keyed by cloud tag.
@@ -62,6 +68,13 @@ type CloudCredentialResults struct {
 	Results []CloudCredentialResult `json:"results,omitempty"`
 }
 
+// ModelCredential holds the cloud credential used by a model.
+type ModelCredential struct {
+	// CloudCredential is the tag of the model's cloud credential, or
+	// empty if the model does not have one.
+	CloudCredential string `json:"credential-tag,omitempty"`
+}
+
 // UserCloud contains a user/cloud tag pair, typically used for identifying
 // a user's credentials for a cloud.
 type UserCloud struct {
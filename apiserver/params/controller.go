@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -87,3 +89,36 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// AuditLogArgs holds the arguments for reading the audit log. Empty
+// fields do not restrict the entries returned.
+type AuditLogArgs struct {
+	// UserTag selects the entries for requests made by this user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// After selects the entries recorded at or after this time.
+	After *time.Time `json:"after,omitempty"`
+
+	// Before selects the entries recorded before this time.
+	Before *time.Time `json:"before,omitempty"`
+
+	// Limit is the maximum number of entries to return; the most
+	// recent entries are returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single entry of the audit log.
+type AuditLogEntry struct {
+	Timestamp     time.Time              `json:"timestamp"`
+	ModelUUID     string                 `json:"model-uuid"`
+	RemoteAddress string                 `json:"remote-address"`
+	OriginType    string                 `json:"origin-type"`
+	OriginName    string                 `json:"origin-name"`
+	Operation     string                 `json:"operation"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogResult holds the entries of the audit log, newest first.
+type AuditLogResult struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -106,6 +106,22 @@ type SetModelAgentVersion struct {
 	Version version.Number `json:"version"`
 }
 
+// DestroyModelsParams holds the arguments for destroying models.
+type DestroyModelsParams struct {
+	Models []DestroyModelParams `json:"models"`
+}
+
+// DestroyModelParams holds the arguments for destroying a model.
+type DestroyModelParams struct {
+	// ModelTag is the tag of the model to destroy.
+	ModelTag string `json:"model-tag"`
+
+	// DestroyStorage controls whether the model's storage is destroyed
+	// along with it. If it is false, destroying a model that contains
+	// storage fails.
+	DestroyStorage bool `json:"destroy-storage,omitempty"`
+}
+
 // ModelMigrationStatus holds information about the progress of a (possibly
 // failed) migration.
 type ModelMigrationStatus struct {
@@ -230,6 +246,22 @@ type ModifyModelAccess struct {
 	Action   ModelAction          `json:"action"`
 	Access   UserAccessPermission `json:"access"`
 	ModelTag string               `json:"model-tag"`
+
+	// Expires, if set on a grant, is when the granted access is
+	// automatically revoked. It is only supported by version 3 and
+	// later of the ModelManager facade.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Group, if set, names the group whose access is changed, and
+	// UserTag is ignored. It is only supported by version 4 and later
+	// of the ModelManager facade.
+	Group string `json:"group,omitempty"`
+
+	// Applications, if set, names the applications in the model on
+	// which write access is changed, rather than the model as a whole.
+	// It is only supported by version 6 and later of the ModelManager
+	// facade.
+	Applications []string `json:"applications,omitempty"`
 }
 
 // ModelAction is an action that can be performed on a model.
@@ -235,6 +235,18 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// PortRangesResult holds the port ranges for an entity, or an error.
+type PortRangesResult struct {
+	Result []PortRange `json:"result,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// PortRangesResults holds the results of an API call that returns
+// port ranges for multiple entities.
+type PortRangesResults struct {
+	Results []PortRangesResult `json:"results"`
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"tag"`
@@ -123,6 +123,23 @@ type AddRelationResults struct {
 	Endpoints map[string]CharmRelation `json:"endpoints"`
 }
 
+// AddRelations holds the parameters for making the AddRelations call.
+type AddRelations struct {
+	Relations []AddRelation `json:"relations"`
+}
+
+// AddRelationsResults holds the results of an AddRelations call.
+type AddRelationsResults struct {
+	Results []AddRelationResult `json:"results"`
+}
+
+// AddRelationResult holds the endpoints of one relation added by the
+// AddRelations call, or the error adding it.
+type AddRelationResult struct {
+	Endpoints map[string]CharmRelation `json:"endpoints,omitempty"`
+	Error     *Error                   `json:"error,omitempty"`
+}
+
 // DestroyRelation holds the parameters for making the DestroyRelation call.
 // The endpoints specified are unordered.
 type DestroyRelation struct {
@@ -283,6 +300,14 @@ type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
 }
 
+// ApplicationExposeEndpoints holds the parameters for making the
+// application ExposeEndpoints call. Endpoints maps endpoint names to
+// the port ranges (e.g. "80/tcp", "8000-8080/tcp") exposed for them.
+type ApplicationExposeEndpoints struct {
+	ApplicationName string              `json:"application"`
+	Endpoints       map[string][]string `json:"endpoints"`
+}
+
 // ApplicationSet holds the parameters for an application Set
 // command. Options contains the configuration data.
 type ApplicationSet struct {
@@ -290,6 +315,12 @@ type ApplicationSet struct {
 	Options         map[string]string `json:"options"`
 }
 
+// ApplicationSetArgs holds the parameters for the SetApplicationsConfig
+// call.
+type ApplicationSetArgs struct {
+	Args []ApplicationSet `json:"args"`
+}
+
 // ApplicationUnset holds the parameters for an application Unset
 // command. Options contains the option attribute names
 // to unset.
@@ -385,6 +416,25 @@ type AddApplicationUnits struct {
 	Placement       []*instance.Placement `json:"placement"`
 }
 
+// AddApplicationUnitsArgs holds parameters for the AddApplicationUnits
+// call.
+type AddApplicationUnitsArgs struct {
+	Args []AddApplicationUnits `json:"args"`
+}
+
+// AddApplicationUnitsResultList holds the results of an
+// AddApplicationUnits call.
+type AddApplicationUnitsResultList struct {
+	Results []AddApplicationUnitsResult `json:"results"`
+}
+
+// AddApplicationUnitsResult holds the names of the units added to one
+// application by the AddApplicationUnits call, or the error adding them.
+type AddApplicationUnitsResult struct {
+	Units []string `json:"units,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
 // DestroyApplicationUnits holds parameters for the DestroyUnits call.
 type DestroyApplicationUnits struct {
 	UnitNames []string `json:"unit-names"`
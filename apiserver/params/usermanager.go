@@ -67,3 +67,26 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// AddGroups holds the parameters for adding new groups.
+type AddGroups struct {
+	Groups []AddGroup `json:"groups"`
+}
+
+// AddGroup stores the parameters to add one group.
+type AddGroup struct {
+	Name string `json:"name"`
+}
+
+// GroupMembers holds the parameters for changing the members of
+// groups.
+type GroupMembers struct {
+	Changes []GroupMember `json:"changes"`
+}
+
+// GroupMember identifies a user and a group they are added to or
+// removed from.
+type GroupMember struct {
+	Group   string `json:"group"`
+	UserTag string `json:"user-tag"`
+}
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
@@ -149,6 +150,13 @@ func (h *registerUserHandler) processPost(req *http.Request, st *state.State) (
 	if err := json.Unmarshal(payloadBytes, &requestPayload); err != nil {
 		return failure(errors.Annotate(err, "cannot unmarshal payload"))
 	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return failure(errors.Trace(err))
+	}
+	if err := common.NewPasswordPolicy(controllerConfig).ValidatePassword(requestPayload.Password); err != nil {
+		return failure(errors.Trace(err))
+	}
 	if err := user.SetPassword(requestPayload.Password); err != nil {
 		return failure(errors.Annotate(err, "setting new password"))
 	}
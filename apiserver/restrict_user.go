@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// userActiveCheckInterval is how long the result of checking that a
+// user is still active is reused for, so that a busy connection does
+// not read the user document on every request.
+const userActiveCheckInterval = 5 * time.Second
+
+// activeUserOnly returns a check for restrictRoot that rejects every
+// API request once the given local user has been disabled or deleted,
+// so that disabling a user also ends the sessions they already have.
+// The user is known to be active at login, and is checked again at
+// most once every userActiveCheckInterval.
+func activeUserOnly(st *state.State, tag names.UserTag, clock clock.Clock) func(string, string) error {
+	check := &activeUserCheck{
+		st:      st,
+		tag:     tag,
+		clock:   clock,
+		active:  true,
+		checked: clock.Now(),
+	}
+	return func(_, _ string) error {
+		active, err := check.isActive()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !active {
+			return common.ErrLoginExpired
+		}
+		return nil
+	}
+}
+
+// activeUserCheck caches whether a user is active for the requests
+// made on a single connection.
+type activeUserCheck struct {
+	st    *state.State
+	tag   names.UserTag
+	clock clock.Clock
+
+	mu      sync.Mutex
+	active  bool
+	checked time.Time
+}
+
+func (c *activeUserCheck) isActive() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if now.Sub(c.checked) < userActiveCheckInterval {
+		return c.active, nil
+	}
+	active, err := isUserActive(c.st, c.tag)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	c.active = active
+	c.checked = now
+	return active, nil
+}
+
+// isUserActive reports whether the local user exists and is neither
+// disabled nor deleted.
+func isUserActive(st *state.State, tag names.UserTag) (bool, error) {
+	user, err := st.User(tag)
+	if _, ok := errors.Cause(err).(state.DeletedUserError); ok || errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return !user.IsDisabled(), nil
+}
@@ -21,16 +21,19 @@ var logger = loggo.GetLogger("juju.apiserver.usermanager")
 
 func init() {
 	common.RegisterStandardFacade("UserManager", 1, NewUserManagerAPI)
+	// Version 2 adds groups.
+	common.RegisterStandardFacade("UserManager", 2, NewUserManagerAPI)
 }
 
 // UserManagerAPI implements the user manager interface and is the concrete
 // implementation of the api end point.
 type UserManagerAPI struct {
-	state      *state.State
-	authorizer facade.Authorizer
-	check      *common.BlockChecker
-	apiUser    names.UserTag
-	isAdmin    bool
+	state          *state.State
+	authorizer     facade.Authorizer
+	check          *common.BlockChecker
+	passwordPolicy common.PasswordPolicy
+	apiUser        names.UserTag
+	isAdmin        bool
 }
 
 func NewUserManagerAPI(
@@ -51,13 +54,18 @@ func NewUserManagerAPI(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	return &UserManagerAPI{
-		state:      st,
-		authorizer: authorizer,
-		check:      common.NewBlockChecker(st),
-		apiUser:    apiUser,
-		isAdmin:    isAdmin,
+		state:          st,
+		authorizer:     authorizer,
+		check:          common.NewBlockChecker(st),
+		passwordPolicy: common.NewPasswordPolicy(controllerConfig),
+		apiUser:        apiUser,
+		isAdmin:        isAdmin,
 	}, nil
 }
 
@@ -106,6 +114,10 @@ func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults,
 		var user *state.User
 		var err error
 		if arg.Password != "" {
+			if err := api.passwordPolicy.ValidatePassword(arg.Password); err != nil {
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
 			user, err = api.state.AddUser(arg.Username, arg.DisplayName, arg.Password, api.apiUser.Id())
 		} else {
 			user, err = api.state.AddUserWithSecretKey(arg.Username, arg.DisplayName, api.apiUser.Id())
@@ -182,6 +194,69 @@ func (api *UserManagerAPI) RemoveUser(entities params.Entities) (params.ErrorRes
 	return deletions, nil
 }
 
+// AddGroup adds groups, initially with no members, to the controller.
+func (api *UserManagerAPI) AddGroup(args params.AddGroups) (params.ErrorResults, error) {
+	var result params.ErrorResults
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return result, common.ErrPerm
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Groups))
+	for i, arg := range args.Groups {
+		if _, err := api.state.AddGroup(arg.Name, api.apiUser); err != nil {
+			err = errors.Annotate(err, "failed to create group")
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// AddGroupMembers adds users to groups.
+func (api *UserManagerAPI) AddGroupMembers(args params.GroupMembers) (params.ErrorResults, error) {
+	return api.changeGroupMembers(args, api.state.AddGroupMember)
+}
+
+// RemoveGroupMembers removes users from groups.
+func (api *UserManagerAPI) RemoveGroupMembers(args params.GroupMembers) (params.ErrorResults, error) {
+	return api.changeGroupMembers(args, api.state.RemoveGroupMember)
+}
+
+func (api *UserManagerAPI) changeGroupMembers(args params.GroupMembers, change func(string, names.UserTag) error) (params.ErrorResults, error) {
+	var result params.ErrorResults
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return result, common.ErrPerm
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Changes))
+	for i, arg := range args.Changes {
+		user, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := change(arg.Group, user); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
 func (api *UserManagerAPI) getUser(tag string) (*state.User, error) {
 	userTag, err := names.ParseUserTag(tag)
 	if err != nil {
@@ -391,6 +466,9 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 	if arg.Password == "" {
 		return errors.New("cannot use an empty password")
 	}
+	if err := api.passwordPolicy.ValidatePassword(arg.Password); err != nil {
+		return errors.Trace(err)
+	}
 	if err := user.SetPassword(arg.Password); err != nil {
 		return errors.Annotate(err, "failed to set password")
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/usermanager"
+	jujucontroller "github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -810,3 +811,147 @@ func (s *userManagerSuite) TestRemoveUserBulkSharedModels(c *gc.C) {
 	c.Assert(alice.IsDeleted(), jc.IsTrue)
 
 }
+
+type passwordPolicySuite struct {
+	userManagerSuite
+}
+
+var _ = gc.Suite(&passwordPolicySuite{})
+
+func (s *passwordPolicySuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		jujucontroller.PasswordMinLength: 10,
+	}
+	s.userManagerSuite.SetUpTest(c)
+}
+
+func (s *passwordPolicySuite) TestAddUserShortPassword(c *gc.C) {
+	args := params.AddUsers{
+		Users: []params.AddUser{{
+			Username: "foobar",
+			Password: "short",
+		}}}
+	result, err := s.usermanager.AddUser(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "password must be at least 10 characters long, got 5")
+
+	_, err = s.State.User(names.NewLocalUserTag("foobar"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *passwordPolicySuite) TestSetPasswordShortPassword(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	args := params.EntityPasswords{
+		Changes: []params.EntityPassword{{
+			Tag:      alex.Tag().String(),
+			Password: "short",
+		}}}
+	results, err := s.usermanager.SetPassword(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "password must be at least 10 characters long, got 5")
+	c.Assert(alex.PasswordValid("short"), jc.IsFalse)
+
+	args.Changes[0].Password = "long-enough-password"
+	results, err = s.usermanager.SetPassword(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *userManagerSuite) TestAddGroup(c *gc.C) {
+	result, err := s.usermanager.AddGroup(params.AddGroups{
+		Groups: []params.AddGroup{{Name: "devs"}, {Name: "Devs!"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `failed to create group: group name "Devs!" not valid`)
+
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.CreatedBy(), gc.Equals, s.adminName)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestAddGroupAsNormalUser(c *gc.C) {
+	chuck := s.Factory.MakeUser(c, &factory.UserParams{Name: "chuck", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: chuck.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = usermanager.AddGroup(params.AddGroups{
+		Groups: []params.AddGroup{{Name: "devs"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.State.Group("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestBlockAddGroup(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockAddGroup")
+	_, err := s.usermanager.AddGroup(params.AddGroups{
+		Groups: []params.AddGroup{{Name: "devs"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockAddGroup")
+
+	_, err = s.State.Group("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestAddRemoveGroupMembers(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	_, err := s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.usermanager.AddGroupMembers(params.GroupMembers{
+		Changes: []params.GroupMember{
+			{Group: "devs", UserTag: bob.Tag().String()},
+			{Group: "ops", UserTag: bob.Tag().String()},
+			{Group: "devs", UserTag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `group "ops" not found`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{bob.UserTag()})
+
+	result, err = s.usermanager.RemoveGroupMembers(params.GroupMembers{
+		Changes: []params.GroupMember{{Group: "devs", UserTag: bob.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.IsNil)
+
+	c.Assert(group.Refresh(), jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestChangeGroupMembersAsNormalUser(c *gc.C) {
+	chuck := s.Factory.MakeUser(c, &factory.UserParams{Name: "chuck", NoModelUser: true})
+	_, err := s.State.AddGroup("devs", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddGroupMember("devs", chuck.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: chuck.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.GroupMembers{
+		Changes: []params.GroupMember{{Group: "devs", UserTag: chuck.Tag().String()}},
+	}
+	_, err = usermanager.AddGroupMembers(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = usermanager.RemoveGroupMembers(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{chuck.UserTag()})
+}
@@ -78,3 +78,34 @@ func (e AuditEntry) Validate() error {
 
 	return nil
 }
+
+// Filter selects audit entries when reading them back.
+type Filter struct {
+	// OriginName, if set, selects only the entries triggered by the
+	// origin with this name.
+	OriginName string
+	// After, if set, selects only the entries recorded at or after
+	// this time.
+	After time.Time
+	// Before, if set, selects only the entries recorded before this
+	// time.
+	Before time.Time
+	// Limit, if positive, is the maximum number of entries to select.
+	// The most recent entries are kept.
+	Limit int
+}
+
+// Matches returns whether the entry is selected by the filter's origin
+// and time constraints. The limit is not considered.
+func (f Filter) Matches(e AuditEntry) bool {
+	if f.OriginName != "" && e.OriginName != f.OriginName {
+		return false
+	}
+	if !f.After.IsZero() && e.Timestamp.Before(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && !e.Timestamp.Before(f.Before) {
+		return false
+	}
+	return true
+}
@@ -103,6 +103,29 @@ func (s *auditSuite) TestValidate_EmptyJujuServerVersionErrors(c *gc.C) {
 	c.Check(validationErr, gc.ErrorMatches, "JujuServerVersion not assigned")
 }
 
+func (s *auditSuite) TestFilter_Matches(c *gc.C) {
+	entry := validEntry()
+	entry.OriginName = "user-bob"
+	entry.Timestamp = time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, t := range []struct {
+		filter  audit.Filter
+		matches bool
+	}{
+		{audit.Filter{}, true},
+		{audit.Filter{OriginName: "user-bob"}, true},
+		{audit.Filter{OriginName: "user-mary"}, false},
+		{audit.Filter{After: entry.Timestamp}, true},
+		{audit.Filter{After: entry.Timestamp.Add(time.Second)}, false},
+		{audit.Filter{Before: entry.Timestamp}, false},
+		{audit.Filter{Before: entry.Timestamp.Add(time.Second)}, true},
+		{audit.Filter{Limit: 1}, true},
+	} {
+		c.Logf("test %d: %#v", i, t.filter)
+		c.Check(t.filter.Matches(entry), gc.Equals, t.matches)
+	}
+}
+
 func validEntry() audit.AuditEntry {
 	return audit.AuditEntry{
 		JujuServerVersion: version.MustParse("1.0.0"),
@@ -5,7 +5,6 @@ package application
 
 import (
 	"regexp"
-	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -52,32 +51,39 @@ Add a unit of mariadb to LXD container number 3 on machine 24:
 
     juju add-unit mariadb --to 24/lxd/3
 
+Add two units of mysql, one to machine 23 and one to a new LXD container
+on machine 7 (the --to option may be repeated or given a comma separated
+list of directives):
+
+    juju add-unit mysql -n 2 --to 23 --to lxd:7
+
 See also: 
     remove-unit`[1:]
 
 // UnitCommandBase provides support for commands which deploy units. It handles the parsing
 // and validation of --to and --num-units arguments.
 type UnitCommandBase struct {
-	// PlacementSpec is the raw string command arg value used to specify placement directives.
-	PlacementSpec string
-	// Placement is the result of parsing the PlacementSpec arg value.
+	// PlacementSpecs holds the raw --to arg values used to specify placement
+	// directives. The flag may be repeated, and each value may itself be a
+	// comma separated list of directives.
+	PlacementSpecs []string
+	// Placement is the result of parsing the PlacementSpecs arg values.
 	Placement []*instance.Placement
 	NumUnits  int
 }
 
 func (c *UnitCommandBase) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumUnits, "num-units", 1, "")
-	f.StringVar(&c.PlacementSpec, "to", "", "The machine and/or container to deploy the unit in (bypasses constraints)")
+	f.Var(cmd.NewAppendStringsValue(&c.PlacementSpecs), "to", "The machine and/or container to deploy the unit in (bypasses constraints)")
 }
 
 func (c *UnitCommandBase) Init(args []string) error {
 	if c.NumUnits < 1 {
 		return errors.New("--num-units must be a positive integer")
 	}
-	if c.PlacementSpec != "" {
-		placementSpecs := strings.Split(c.PlacementSpec, ",")
-		c.Placement = make([]*instance.Placement, len(placementSpecs))
-		for i, spec := range placementSpecs {
+	if len(c.PlacementSpecs) > 0 {
+		c.Placement = make([]*instance.Placement, len(c.PlacementSpecs))
+		for i, spec := range c.PlacementSpecs {
 			placement, err := parsePlacement(spec)
 			if err != nil {
 				return errors.Errorf("invalid --to parameter %q", spec)
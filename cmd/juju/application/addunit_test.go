@@ -126,6 +126,17 @@ func (s *AddUnitSuite) TestAddUnitWithPlacement(c *gc.C) {
 	})
 }
 
+func (s *AddUnitSuite) TestAddUnitWithRepeatedPlacement(c *gc.C) {
+	err := s.runAddUnit(c, "--num-units", "3", "--to", "123", "--to", "lxd:1,1/lxd/2", "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.numUnits, gc.Equals, 4)
+	c.Assert(s.fake.placement, jc.DeepEquals, []*instance.Placement{
+		{"#", "123"},
+		{"lxd", "1"},
+		{"#", "1/lxd/2"},
+	})
+}
+
 func (s *AddUnitSuite) TestBlockAddUnit(c *gc.C) {
 	// Block operation
 	s.fake.err = common.OperationBlockedError("TestBlockAddUnit")
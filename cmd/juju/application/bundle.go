@@ -51,7 +51,8 @@ type deploymentLogger interface {
 
 // deployBundle deploys the given bundle data using the given API client and
 // charm store client. The deployment is not transactional, and its progress is
-// notified using the given deployment logger.
+// notified using the given deployment logger. If dryRun is true, the changes
+// required to deploy the bundle are notified instead of being applied.
 func deployBundle(
 	bundleFilePath string,
 	data *charm.BundleData,
@@ -59,6 +60,7 @@ func deployBundle(
 	apiRoot DeployAPI,
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
+	dryRun bool,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -94,6 +96,21 @@ func deployBundle(
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get model status")
 	}
+	if dryRun {
+		descriptions, err := describeChanges(changes, data, status)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(descriptions) == 0 {
+			log.Infof("No changes to apply.")
+			return nil, nil
+		}
+		log.Infof("Changes to deploy bundle:")
+		for _, description := range descriptions {
+			log.Infof("- %s", description)
+		}
+		return nil, nil
+	}
 	unitStatus := make(map[string]string, numChanges)
 	for _, serviceData := range status.Applications {
 		for unit, unitData := range serviceData.Units {
@@ -167,6 +184,192 @@ func deployBundle(
 	return csMacs, nil
 }
 
+// describeChanges returns a human readable description of each of the
+// given bundle changes that would be applied to a model with the given
+// status. Changes are skipped the same way they are when the bundle is
+// deployed: existing applications using the bundle's charm are reused,
+// no units or machines are added for applications that already have
+// enough units, and existing relations and exposed applications are
+// left alone.
+func describeChanges(changes []bundlechanges.Change, data *charm.BundleData, status *params.FullStatus) ([]string, error) {
+	// names holds the description of the entity resulting from each
+	// change, for resolving placeholders in later changes.
+	names := make(map[string]string, len(changes))
+	var descriptions []string
+	// placement resolves a machine placement, which is usually but
+	// not always a placeholder.
+	placement := func(to string) string {
+		if strings.HasPrefix(to, "$") {
+			return resolve(to, names)
+		}
+		return to
+	}
+	// units holds the number of units each application has, including
+	// the units that the changes described so far would add.
+	units := make(map[string]int, len(status.Applications))
+	for name, application := range status.Applications {
+		units[name] = len(application.Units)
+	}
+	hasEnoughUnits := func(applications ...string) bool {
+		for _, application := range applications {
+			if units[application] < data.Applications[application].NumUnits {
+				return false
+			}
+		}
+		return true
+	}
+	// h is only used to find the applications a new machine is for.
+	h := &bundleHandler{changes: changes, data: data, results: names}
+	newMachines := 0
+	newUnits := make(map[string]int)
+	for _, change := range changes {
+		var description string
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			p := change.Params
+			names[change.Id()] = p.Charm
+			if charmInUse(status, p.Charm) {
+				continue
+			}
+			description = fmt.Sprintf("upload charm %s", p.Charm)
+			if p.Series != "" {
+				description += fmt.Sprintf(" for series %s", p.Series)
+			}
+		case *bundlechanges.AddMachineChange:
+			p := change.Params
+			if applications := h.servicesForMachineChange(change.Id()); len(applications) > 0 && hasEnoughUnits(applications...) {
+				names[change.Id()] = "an existing machine"
+				continue
+			}
+			newMachines++
+			name := fmt.Sprintf("new machine %d", newMachines)
+			if p.ContainerType != "" {
+				parent := "a new machine"
+				if p.ParentId != "" {
+					parent = placement(p.ParentId)
+				}
+				name = fmt.Sprintf("new %s container %d on %s", p.ContainerType, newMachines, parent)
+			}
+			names[change.Id()] = name
+			description = "add " + name
+			if p.Series != "" {
+				description += fmt.Sprintf(" with series %s", p.Series)
+			}
+		case *bundlechanges.AddRelationChange:
+			p := change.Params
+			ep1 := resolveRelation(p.Endpoint1, names)
+			ep2 := resolveRelation(p.Endpoint2, names)
+			if relationExists(status, ep1, ep2) {
+				continue
+			}
+			description = fmt.Sprintf("add relation %s - %s", ep1, ep2)
+		case *bundlechanges.AddApplicationChange:
+			p := change.Params
+			names[change.Id()] = p.Application
+			ch := resolve(p.Charm, names)
+			if existing, ok := status.Applications[p.Application]; ok {
+				if sameCharm(existing.Charm, ch) {
+					continue
+				}
+				description = fmt.Sprintf("upgrade application %s from %s to %s", p.Application, existing.Charm, ch)
+				break
+			}
+			description = fmt.Sprintf("deploy application %s using %s", p.Application, ch)
+			if p.Series != "" {
+				description += fmt.Sprintf(" on %s", p.Series)
+			}
+		case *bundlechanges.AddUnitChange:
+			p := change.Params
+			application := resolve(p.Application, names)
+			if hasEnoughUnits(application) {
+				names[change.Id()] = fmt.Sprintf("an existing machine of %s", application)
+				continue
+			}
+			units[application]++
+			newUnits[application]++
+			names[change.Id()] = fmt.Sprintf("the machine of new %s unit %d", application, newUnits[application])
+			description = fmt.Sprintf("add %s unit %d", application, newUnits[application])
+			if p.To != "" {
+				description += " to " + placement(p.To)
+			}
+		case *bundlechanges.ExposeChange:
+			application := resolve(change.Params.Application, names)
+			if status.Applications[application].Exposed {
+				continue
+			}
+			description = fmt.Sprintf("expose %s", application)
+		case *bundlechanges.SetAnnotationsChange:
+			p := change.Params
+			entity := resolve(p.Id, names)
+			if p.EntityType == bundlechanges.ApplicationType {
+				entity = "application " + entity
+			}
+			description = fmt.Sprintf("set annotations for %s", entity)
+		default:
+			return nil, errors.Errorf("unknown change type: %T", change)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions, nil
+}
+
+// charmInUse reports whether any application in the model already
+// uses the given charm.
+func charmInUse(status *params.FullStatus, ch string) bool {
+	for _, application := range status.Applications {
+		if sameCharm(application.Charm, ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameCharm reports whether the existing charm URL refers to the bundle
+// charm. A bundle charm without a series or revision matches an
+// existing charm with any series or revision.
+func sameCharm(existing, ch string) bool {
+	existingURL, err := charm.ParseURL(existing)
+	if err != nil {
+		return existing == ch
+	}
+	url, err := charm.ParseURL(ch)
+	if err != nil {
+		return existing == ch
+	}
+	match := *url
+	if match.Series == "" {
+		match.Series = existingURL.Series
+	}
+	if match.Revision < 0 {
+		match.Revision = existingURL.Revision
+	}
+	return match.String() == existingURL.String()
+}
+
+// relationExists reports whether the model already has a relation
+// between the given endpoints, which may omit the relation name.
+func relationExists(status *params.FullStatus, ep1, ep2 string) bool {
+	for _, relation := range status.Relations {
+		if hasEndpoint(relation.Endpoints, ep1) && hasEndpoint(relation.Endpoints, ep2) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEndpoint(endpoints []params.EndpointStatus, ep string) bool {
+	parts := strings.SplitN(ep, ":", 2)
+	for _, endpoint := range endpoints {
+		if endpoint.ApplicationName != parts[0] {
+			continue
+		}
+		if len(parts) == 1 || endpoint.Name == parts[1] {
+			return true
+		}
+	}
+	return false
+}
+
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	// bundleDir is the path where the bundle file is located for local bundles.
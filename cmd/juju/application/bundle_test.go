@@ -233,6 +233,81 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleLocalPath(c *gc.C) {
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleDryRun(c *gc.C) {
+	output, err := s.DeployBundleYAMLWithArgs(c, `
+        applications:
+            mysql:
+                charm: cs:xenial/mysql-42
+                num_units: 1
+                expose: true
+            wordpress:
+                charm: cs:xenial/wordpress-47
+                num_units: 1
+                to: [1]
+        machines:
+            1:
+        relations:
+            - ["wordpress:db", "mysql:server"]
+    `, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, gc.Equals, ""+
+		"Changes to deploy bundle:\n"+
+		"- upload charm cs:xenial/mysql-42 for series xenial\n"+
+		"- deploy application mysql using cs:xenial/mysql-42 on xenial\n"+
+		"- expose mysql\n"+
+		"- upload charm cs:xenial/wordpress-47 for series xenial\n"+
+		"- deploy application wordpress using cs:xenial/wordpress-47 on xenial\n"+
+		"- add new machine 1\n"+
+		"- add relation wordpress:db - mysql:server\n"+
+		"- add mysql unit 1\n"+
+		"- add wordpress unit 1 to new machine 1",
+	)
+	s.assertCharmsUploaded(c)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+	s.assertUnitsCreated(c, map[string]string{})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleDryRunSkipsExisting(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	bundle := `
+        applications:
+            mysql:
+                charm: cs:xenial/mysql-42
+                num_units: 1
+                expose: true
+            wordpress:
+                charm: cs:xenial/wordpress-47
+                num_units: %d
+        relations:
+            - ["wordpress:db", "mysql:server"]
+    `
+	_, err := s.DeployBundleYAML(c, fmt.Sprintf(bundle, 1))
+	c.Assert(err, jc.ErrorIsNil)
+
+	output, err := s.DeployBundleYAMLWithArgs(c, fmt.Sprintf(bundle, 1), "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, gc.Equals, "No changes to apply.")
+
+	output, err = s.DeployBundleYAMLWithArgs(c, fmt.Sprintf(bundle, 3), "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, gc.Equals, ""+
+		"Changes to deploy bundle:\n"+
+		"- add wordpress unit 1\n"+
+		"- add wordpress unit 2",
+	)
+	s.assertUnitsCreated(c, map[string]string{
+		"mysql/0":     "0",
+		"wordpress/0": "1",
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployCharmDryRun(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	_, err := runDeployCommand(c, "xenial/mysql-42", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "Flags provided but not supported when deploying a charm: --dry-run.")
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleNoSeriesInCharmURL(c *gc.C) {
 	testcharms.UploadCharmMultiSeries(c, s.client, "~who/multi-series", "multi-series")
 	dir := c.MkDir()
@@ -282,6 +357,10 @@ func (s *BundleDeployCharmStoreSuite) Client() *csclient.Client {
 // local repository and then deploy it. It returns the bundle deployment output
 // and error.
 func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string) (string, error) {
+	return s.DeployBundleYAMLWithArgs(c, content)
+}
+
+func (s *BundleDeployCharmStoreSuite) DeployBundleYAMLWithArgs(c *gc.C, content string, args ...string) (string, error) {
 	bundlePath := filepath.Join(c.MkDir(), "example")
 	c.Assert(os.Mkdir(bundlePath, 0777), jc.ErrorIsNil)
 	defer os.RemoveAll(bundlePath)
@@ -289,7 +368,7 @@ func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string)
 	c.Assert(err, jc.ErrorIsNil)
 	err = ioutil.WriteFile(filepath.Join(bundlePath, "README.md"), []byte("README"), 0644)
 	c.Assert(err, jc.ErrorIsNil)
-	return runDeployCommand(c, bundlePath)
+	return runDeployCommand(c, bundlePath, args...)
 }
 
 var deployBundleErrorsTests = []struct {
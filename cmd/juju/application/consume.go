@@ -20,8 +20,9 @@ var usageConsumeDetails = `
 Adds a remote application to the model. Relations can be created later using "juju relate".
 
 The remote application can be identified in two ways:
-    [<model owner>/]<model name>.<application name>
-        for an application in another model in this controller (if owner isn't specified it's assumed to be the logged-in user)
+    [<controller>:][<model owner>/]<model name>.<application name>
+        for an application in another model in this controller (if owner isn't specified it's assumed to be the logged-in user);
+        consuming applications from other controllers is not yet supported
 or
     <remote endpoint url>
         for remote applications that have been shared using the offer command
@@ -48,6 +49,10 @@ type consumeCommand struct {
 	api               applicationConsumeAPI
 	remoteApplication string
 	applicationAlias  string
+
+	// sourceController holds the controller named in the remote
+	// application URL, if any.
+	sourceController string
 }
 
 // Info implements cmd.Command.
@@ -73,6 +78,7 @@ func (c *consumeCommand) Init(args []string) error {
 	if url.HasEndpoint() {
 		return errors.Errorf("remote application %q shouldn't include endpoint", c.remoteApplication)
 	}
+	c.sourceController = url.Source
 	if len(args) > 1 {
 		if !names.IsValidApplication(args[1]) {
 			return errors.Errorf("invalid application name %q", args[1])
@@ -97,6 +103,12 @@ func (c *consumeCommand) getAPI() (applicationConsumeAPI, error) {
 // Run adds the requested remote application to the model. Implements
 // cmd.Command.
 func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	// Relation data can only flow between models hosted by the same
+	// controller, so refuse offers from anywhere else rather than
+	// silently looking the model up locally.
+	if c.sourceController != "" && c.sourceController != c.ControllerName() {
+		return errors.NotSupportedf("consuming applications from controller %q", c.sourceController)
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return err
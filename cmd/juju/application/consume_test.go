@@ -60,6 +60,12 @@ func (s *ConsumeSuite) TestErrorFromAPI(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "infirmary")
 }
 
+func (s *ConsumeSuite) TestOtherControllerNotSupported(c *gc.C) {
+	_, err := s.runConsume(c, "othercontroller:fred/booster.uke")
+	c.Assert(err, gc.ErrorMatches, `consuming applications from controller "othercontroller" not supported`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
 func (s *ConsumeSuite) TestSuccessModelDotApplication(c *gc.C) {
 	s.mockAPI.localName = "mary-weep"
 	ctx, err := s.runConsume(c, "booster.uke")
@@ -250,6 +250,10 @@ type DeployCommand struct {
 	// running an unsupported series.
 	Force bool
 
+	// DryRun is used to show the changes required to deploy a bundle
+	// without applying them.
+	DryRun bool
+
 	ApplicationName string
 	Config          cmd.FileVar
 	ConstraintsStr  string
@@ -318,6 +322,11 @@ For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+The changes required to deploy a bundle can be shown, without applying
+them, by specifying the '--dry-run' option:
+
+  juju deploy /path/to/bundle/openstack/bundle.yaml --dry-run
+
 If an 'application name' is not provided, the application name used is the
 'charm or bundle' name.
 
@@ -364,6 +373,9 @@ Examples:
     juju deploy mysql --to host.maas
     (deploy to a specific MAAS node)
 
+    juju deploy mysql -n 3 --to 23 --to 24/lxd/3 --to lxd:25
+    (deploy 3 units, one for each placement directive)
+
     juju deploy mysql -n 5 --constraints mem=8G
     (deploy 5 units to machines with at least 8 GB of memory)
 
@@ -417,7 +429,7 @@ var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
 	charmOnlyFlags        = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
-	bundleOnlyFlags       = []string{}
+	bundleOnlyFlags       = []string{"dry-run"}
 	modelCommandBaseFlags = []string{"B", "no-browser-login"}
 )
 
@@ -432,6 +444,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the changes required to deploy a bundle without applying them")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
@@ -443,7 +456,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *DeployCommand) Init(args []string) error {
-	if c.Force && c.Series == "" && c.PlacementSpec == "" {
+	if c.Force && c.Series == "" && len(c.PlacementSpecs) == 0 {
 		return errors.New("--force is only used with --series")
 	}
 	switch len(args) {
@@ -497,9 +510,13 @@ func (c *DeployCommand) deployBundle(
 		apiRoot,
 		ctx,
 		bundleStorage,
+		c.DryRun,
 	); err != nil {
 		return errors.Trace(err)
 	}
+	if c.DryRun {
+		return nil
+	}
 	ctx.Infof("Deploy of bundle completed.")
 	return nil
 }
@@ -521,7 +538,7 @@ func (c *DeployCommand) deployCharm(
 		if !constraints.IsEmpty(&c.Constraints) {
 			return errors.New("cannot use --constraints with subordinate application")
 		}
-		if numUnits == 1 && c.PlacementSpec == "" {
+		if numUnits == 1 && len(c.PlacementSpecs) == 0 {
 			numUnits = 0
 		} else {
 			return errors.New("cannot use --num-units or --to with subordinate application")
@@ -53,6 +53,14 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewScaleApplicationCommandForTest returns a ScaleApplicationCommand with the api provided as specified.
+func NewScaleApplicationCommandForTest(api scaleApplicationAPI) cmd.Command {
+	cmd := &scaleApplicationCommand{newAPIFunc: func() (scaleApplicationAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
 func NewAddRelationCommandForTest(api ApplicationAddRelationAPI) cmd.Command {
 	cmd := &addRelationCommand{newAPIFunc: func() (ApplicationAddRelationAPI, error) {
@@ -4,12 +4,17 @@
 package application
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
 )
 
 var usageExposeSummary = `
@@ -19,8 +24,16 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default every port opened by the application's units is made public.
+The --endpoint option restricts access to the given port ranges of a
+named endpoint; it may be repeated to expose several endpoints. Only the
+opened ports within those ranges are made public. Exposing endpoints
+replaces any endpoints previously exposed, and exposing the application
+without --endpoint makes all of its opened ports public again.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --endpoint website=80/tcp,443/tcp
 
 See also: 
     unexpose`[1:]
@@ -34,6 +47,7 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       map[string][]network.PortRange
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,6 +59,11 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(endpointPortsValue{&c.Endpoints}, "endpoint", "Expose only the given port ranges of an endpoint (<endpoint>=<port-range>[,...])")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -56,6 +75,7 @@ func (c *exposeCommand) Init(args []string) error {
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeEndpoints(serviceName string, endpoints map[string][]network.PortRange) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +95,54 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.Endpoints) > 0 {
+		err = client.ExposeEndpoints(c.ApplicationName, c.Endpoints)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// endpointPortsValue implements gnuflag.Value for the --endpoint option,
+// collecting the port ranges given for each endpoint name.
+type endpointPortsValue struct {
+	endpoints *map[string][]network.PortRange
+}
+
+// Set implements gnuflag.Value.
+func (v endpointPortsValue) Set(value string) error {
+	name, specs := value, ""
+	if i := strings.Index(value, "="); i >= 0 {
+		name, specs = value[:i], value[i+1:]
+	}
+	if name == "" || specs == "" {
+		return errors.Errorf("expected <endpoint>=<port-range>[,...], got %q", value)
+	}
+	var portRanges []network.PortRange
+	for _, spec := range strings.Split(specs, ",") {
+		portRange, err := network.ParsePortRange(spec)
+		if err != nil {
+			return errors.Annotatef(err, "endpoint %q", name)
+		}
+		portRanges = append(portRanges, portRange)
+	}
+	if *v.endpoints == nil {
+		*v.endpoints = make(map[string][]network.PortRange)
+	}
+	(*v.endpoints)[name] = append((*v.endpoints)[name], portRanges...)
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v endpointPortsValue) String() string {
+	var values []string
+	for name, portRanges := range *v.endpoints {
+		specs := make([]string, len(portRanges))
+		for i, portRange := range portRanges {
+			specs[i] = portRange.String()
+		}
+		values = append(values, name+"="+strings.Join(specs, ","))
+	}
+	sort.Strings(values)
+	return strings.Join(values, " ")
 }
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
@@ -59,6 +60,33 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeEndpoints(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--endpoint", "juju-info=80/tcp,443/tcp", "--endpoint", "juju-info=8000-8080/tcp")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string][]network.PortRange{
+		"juju-info": {
+			network.MustParsePortRange("80/tcp"),
+			network.MustParsePortRange("443/tcp"),
+			network.MustParsePortRange("8000-8080/tcp"),
+		},
+	})
+}
+
+func (s *ExposeSuite) TestExposeEndpointsInvalid(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--endpoint", "juju-info")
+	c.Assert(err, gc.ErrorMatches, `invalid value "juju-info" for flag --endpoint: expected <endpoint>=<port-range>\[,...\], got "juju-info"`)
+
+	err = runExpose(c, "some-application-name", "--endpoint", "juju-info=http")
+	c.Assert(err, gc.ErrorMatches, `invalid value "juju-info=http" for flag --endpoint: endpoint "juju-info": .*`)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
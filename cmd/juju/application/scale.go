@@ -0,0 +1,251 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+)
+
+var usageScaleApplicationSummary = `
+Sets the number of units of an application.`[1:]
+
+var usageScaleApplicationDetails = `
+Adds or removes units so that the application has the given number of
+units. New units are placed according to the application and model
+constraints, as with add-unit.
+
+When units need to be removed, units in an error state are removed
+first, then units sharing their machine with units of other
+applications, and finally the most recently added units.
+
+Examples:
+    juju scale-application wordpress 5
+
+See also:
+    add-unit
+    remove-unit`[1:]
+
+// NewScaleApplicationCommand returns a command which sets the number of
+// units of an application.
+func NewScaleApplicationCommand() cmd.Command {
+	cmd := &scaleApplicationCommand{}
+	cmd.newAPIFunc = func() (scaleApplicationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return scaleApplicationClient{
+			Client:      root.Client(),
+			application: application.NewClient(root),
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// scaleApplicationCommand is responsible for adding and removing
+// units to reach a target unit count.
+type scaleApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	NumUnits        int
+	newAPIFunc      func() (scaleApplicationAPI, error)
+}
+
+func (c *scaleApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "scale-application",
+		Args:    "<application name> <number of units>",
+		Purpose: usageScaleApplicationSummary,
+		Doc:     usageScaleApplicationDetails,
+	}
+}
+
+func (c *scaleApplicationCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application specified")
+	case 1:
+		return errors.New("no unit count specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	numUnits, err := strconv.Atoi(args[1])
+	if err != nil || numUnits < 0 {
+		return errors.Errorf("invalid unit count %q", args[1])
+	}
+	c.NumUnits = numUnits
+	return cmd.CheckEmpty(args[2:])
+}
+
+// scaleApplicationAPI defines the methods on the client API
+// that the scale-application command calls.
+type scaleApplicationAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+	AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error)
+	DestroyUnits(unitNames ...string) ([]params.DestroyUnitResult, error)
+}
+
+// scaleApplicationClient combines the client and application facades
+// into a scaleApplicationAPI.
+type scaleApplicationClient struct {
+	*api.Client
+	application *application.Client
+}
+
+func (c scaleApplicationClient) AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error) {
+	return c.application.AddUnits(application, numUnits, placement)
+}
+
+func (c scaleApplicationClient) DestroyUnits(unitNames ...string) ([]params.DestroyUnitResult, error) {
+	return c.application.DestroyUnits(unitNames...)
+}
+
+// Run connects to the model specified on the command line and adds or
+// removes units of the application until it has the requested number.
+func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	fullStatus, err := client.Status(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, ok := fullStatus.Applications[c.ApplicationName]
+	if !ok {
+		return errors.NotFoundf("application %q", c.ApplicationName)
+	}
+	if len(app.SubordinateTo) > 0 {
+		return errors.Errorf("cannot scale subordinate application %q", c.ApplicationName)
+	}
+
+	// Units that are already going away neither count towards the
+	// current number of units nor can be removed again.
+	units := aliveUnits(app)
+	current := len(units)
+	switch {
+	case c.NumUnits > current:
+		added, err := client.AddUnits(c.ApplicationName, c.NumUnits-current, nil)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		for _, name := range added {
+			ctx.Infof("added unit %s", name)
+		}
+	case c.NumUnits < current:
+		candidates := removalCandidates(fullStatus, units)
+		unitNames := candidates[:current-c.NumUnits]
+		results, err := client.DestroyUnits(unitNames...)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockRemove)
+		}
+		anyFailed := false
+		for i, name := range unitNames {
+			if results[i].Error != nil {
+				anyFailed = true
+				ctx.Infof("removing unit %s failed: %s", name, results[i].Error)
+				continue
+			}
+			ctx.Infof("removing unit %s", name)
+		}
+		if anyFailed {
+			return cmd.ErrSilent
+		}
+	default:
+		ctx.Infof("application %q already has %d unit(s)", c.ApplicationName, current)
+	}
+	return nil
+}
+
+// removalCandidate holds the properties of a unit that determine how
+// readily it is chosen for removal.
+type removalCandidate struct {
+	name   string
+	number int
+	errors bool
+	shared bool
+}
+
+// byRemovalPreference sorts removal candidates so that units in error
+// come first, then units on shared machines, then the newest units.
+type byRemovalPreference []removalCandidate
+
+func (s byRemovalPreference) Len() int      { return len(s) }
+func (s byRemovalPreference) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byRemovalPreference) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.errors != b.errors {
+		return a.errors
+	}
+	if a.shared != b.shared {
+		return a.shared
+	}
+	return a.number > b.number
+}
+
+// aliveUnits returns the units of the application that are neither
+// dying nor dead.
+func aliveUnits(app params.ApplicationStatus) map[string]params.UnitStatus {
+	result := make(map[string]params.UnitStatus)
+	for name, unit := range app.Units {
+		switch params.Life(unit.AgentStatus.Life) {
+		case params.Dying, params.Dead:
+			continue
+		}
+		result[name] = unit
+	}
+	return result
+}
+
+// removalCandidates returns the names of the given units, ordered by
+// preference for removal: units in error first, then units on machines
+// hosting units of other applications, then the most recently added
+// units.
+func removalCandidates(fullStatus *params.FullStatus, units map[string]params.UnitStatus) []string {
+	unitsByMachine := make(map[string]int)
+	for _, app := range fullStatus.Applications {
+		for _, unit := range app.Units {
+			if unit.Machine != "" {
+				unitsByMachine[unit.Machine]++
+			}
+		}
+	}
+
+	var candidates []removalCandidate
+	for name, unit := range units {
+		number, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+		candidates = append(candidates, removalCandidate{
+			name:   name,
+			number: number,
+			errors: unit.WorkloadStatus.Status == string(status.Error) ||
+				unit.AgentStatus.Status == string(status.Error),
+			shared: unitsByMachine[unit.Machine] > 1,
+		})
+	}
+	sort.Sort(byRemovalPreference(candidates))
+	result := make([]string, len(candidates))
+	for i, c := range candidates {
+		result[i] = c.name
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ScaleApplicationSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockScaleAPI
+}
+
+func (s *ScaleApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockScaleAPI{
+		Stub: &testing.Stub{},
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"wordpress": {
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "0"},
+						"wordpress/1": {Machine: "1"},
+						"wordpress/2": {Machine: "2"},
+						"wordpress/3": {Machine: "3"},
+					},
+				},
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Machine: "1"},
+					},
+				},
+				"logging": {
+					SubordinateTo: []string{"wordpress"},
+				},
+			},
+		},
+	}
+}
+
+var _ = gc.Suite(&ScaleApplicationSuite{})
+
+func (s *ScaleApplicationSuite) runScale(c *gc.C, args ...string) error {
+	_, err := coretesting.RunCommand(c, NewScaleApplicationCommandForTest(s.mockAPI), args...)
+	return err
+}
+
+func (s *ScaleApplicationSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"wordpress"},
+		err:  "no unit count specified",
+	}, {
+		args: []string{"wordpress", "-1"},
+		err:  `invalid unit count "-1"`,
+	}, {
+		args: []string{"wordpress", "many"},
+		err:  `invalid unit count "many"`,
+	}, {
+		args: []string{"Wordpress", "2"},
+		err:  `invalid application name "Wordpress"`,
+	}, {
+		args: []string{"wordpress", "2", "3"},
+		err:  `unrecognized args: \["3"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.runScale(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ScaleApplicationSuite) TestScaleUp(c *gc.C) {
+	err := s.runScale(c, "wordpress", "6")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Status", "AddUnits", "Close")
+	s.mockAPI.CheckCall(c, 1, "AddUnits", "wordpress", 2, []*instance.Placement(nil))
+}
+
+func (s *ScaleApplicationSuite) TestScaleDown(c *gc.C) {
+	// wordpress/2 is in error, and wordpress/1 shares its machine
+	// with mysql/0, so they go first; the newest of the rest follows.
+	s.mockAPI.status.Applications["wordpress"].Units["wordpress/2"] = params.UnitStatus{
+		Machine:        "2",
+		WorkloadStatus: params.DetailedStatus{Status: "error"},
+	}
+	err := s.runScale(c, "wordpress", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Status", "DestroyUnits", "Close")
+	s.mockAPI.CheckCall(c, 1, "DestroyUnits", []string{"wordpress/2", "wordpress/1", "wordpress/3"})
+}
+
+func (s *ScaleApplicationSuite) TestScaleIgnoresDyingUnits(c *gc.C) {
+	// wordpress/3 is already being removed, so only three units
+	// remain and it is not chosen again.
+	s.mockAPI.status.Applications["wordpress"].Units["wordpress/3"] = params.UnitStatus{
+		Machine:     "3",
+		AgentStatus: params.DetailedStatus{Life: "dying"},
+	}
+	err := s.runScale(c, "wordpress", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Status", "DestroyUnits", "Close")
+	s.mockAPI.CheckCall(c, 1, "DestroyUnits", []string{"wordpress/1"})
+}
+
+func (s *ScaleApplicationSuite) TestScaleUpIgnoresDyingUnits(c *gc.C) {
+	s.mockAPI.status.Applications["wordpress"].Units["wordpress/3"] = params.UnitStatus{
+		Machine:     "3",
+		AgentStatus: params.DetailedStatus{Life: "dying"},
+	}
+	err := s.runScale(c, "wordpress", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Status", "AddUnits", "Close")
+	s.mockAPI.CheckCall(c, 1, "AddUnits", "wordpress", 1, []*instance.Placement(nil))
+}
+
+func (s *ScaleApplicationSuite) TestScaleUnchanged(c *gc.C) {
+	err := s.runScale(c, "wordpress", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Status", "Close")
+}
+
+func (s *ScaleApplicationSuite) TestScaleNotFound(c *gc.C) {
+	err := s.runScale(c, "foo", "4")
+	c.Assert(err, gc.ErrorMatches, `application "foo" not found`)
+}
+
+func (s *ScaleApplicationSuite) TestScaleSubordinate(c *gc.C) {
+	err := s.runScale(c, "logging", "2")
+	c.Assert(err, gc.ErrorMatches, `cannot scale subordinate application "logging"`)
+}
+
+type mockScaleAPI struct {
+	*testing.Stub
+	status *params.FullStatus
+}
+
+func (s *mockScaleAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockScaleAPI) Status(patterns []string) (*params.FullStatus, error) {
+	s.MethodCall(s, "Status", patterns)
+	return s.status, s.NextErr()
+}
+
+func (s *mockScaleAPI) AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error) {
+	s.MethodCall(s, "AddUnits", application, numUnits, placement)
+	return nil, s.NextErr()
+}
+
+func (s *mockScaleAPI) DestroyUnits(unitNames ...string) ([]params.DestroyUnitResult, error) {
+	s.MethodCall(s, "DestroyUnits", unitNames)
+	return make([]params.DestroyUnitResult, len(unitNames)), s.NextErr()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -160,6 +161,9 @@ Charm settings may be added or updated at upgrade time by specifying the
 
   juju upgrade-charm foo --config config.yaml
 
+Once the upgrade is done, any config settings added or removed by the new
+charm are listed.
+
 If the new version of a charm does not explicitly support the application's series, the
 upgrade is disallowed unless the --force-series flag is used. This option should be
 used with caution since using a charm on a machine running an unsupported series may
@@ -341,7 +345,47 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
-	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
+	if err := charmUpgradeClient.SetCharm(cfg); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	reportConfigChanges(ctx, charmsClient, oldURL, chID.URL)
+	return nil
+}
+
+// reportConfigChanges tells the user which config settings were added
+// and removed between the old and new charms. Failing to fetch either
+// charm's config is not fatal, since the upgrade has already happened.
+func reportConfigChanges(ctx *cmd.Context, client CharmClient, oldURL, newURL *charm.URL) {
+	oldKeys, err := charmConfigKeys(client, oldURL)
+	if err != nil {
+		logger.Warningf("cannot get config for charm %q: %v", oldURL, err)
+		return
+	}
+	newKeys, err := charmConfigKeys(client, newURL)
+	if err != nil {
+		logger.Warningf("cannot get config for charm %q: %v", newURL, err)
+		return
+	}
+	if added := newKeys.Difference(oldKeys); !added.IsEmpty() {
+		ctx.Infof("Added config settings: %s", strings.Join(added.SortedValues(), ", "))
+	}
+	if removed := oldKeys.Difference(newKeys); !removed.IsEmpty() {
+		ctx.Infof("Removed config settings: %s", strings.Join(removed.SortedValues(), ", "))
+	}
+}
+
+func charmConfigKeys(client CharmClient, curl *charm.URL) (set.Strings, error) {
+	charmInfo, err := client.CharmInfo(curl.String())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := set.NewStrings()
+	if charmInfo.Config != nil {
+		for key := range charmInfo.Config.Options {
+			keys.Add(key)
+		}
+	}
+	return keys, nil
 }
 
 // upgradeResources pushes metadata up to the server for each resource defined
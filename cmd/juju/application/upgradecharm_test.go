@@ -195,6 +195,43 @@ func (s *UpgradeCharmSuite) TestStorageConstraintsMinFacadeVersionNoServerVersio
 		"updating storage constraints at upgrade-charm time is not supported by this server")
 }
 
+func (s *UpgradeCharmSuite) TestReportsConfigChanges(c *gc.C) {
+	option := charm.Option{Type: "string"}
+	s.charmClient.charmInfos = map[string]*charms.CharmInfo{
+		"cs:quantal/foo-1": {
+			Meta: &charm.Meta{},
+			Config: &charm.Config{Options: map[string]charm.Option{
+				"kept": option, "gone": option,
+			}},
+		},
+		"cs:quantal/foo-2": {
+			Meta: &charm.Meta{},
+			Config: &charm.Config{Options: map[string]charm.Option{
+				"kept": option, "new-b": option, "new-a": option,
+			}},
+		},
+	}
+	ctx, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "SetCharm")
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "Added config settings: new-a, new-b\n")
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "Removed config settings: gone\n")
+}
+
+func (s *UpgradeCharmSuite) TestNoConfigChangesReported(c *gc.C) {
+	ctx, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), gc.Not(jc.Contains), "config settings")
+}
+
+func (s *UpgradeCharmSuite) TestConfigChangesNotReportedOnError(c *gc.C) {
+	s.charmUpgradeClient.SetErrors(nil, errors.New("boom"))
+	ctx, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.charmClient.CheckCallNames(c, "CharmInfo")
+	c.Assert(coretesting.Stderr(ctx), gc.Not(jc.Contains), "config settings")
+}
+
 func (s *UpgradeCharmSuite) TestConfigSettings(c *gc.C) {
 	tempdir := c.MkDir()
 	configFile := filepath.Join(tempdir, "config.yaml")
@@ -704,6 +741,8 @@ type mockCharmClient struct {
 	CharmClient
 	testing.Stub
 	charmInfo *charms.CharmInfo
+	// charmInfos, if set, overrides charmInfo for specific charm URLs.
+	charmInfos map[string]*charms.CharmInfo
 }
 
 func (m *mockCharmClient) CharmInfo(curl string) (*charms.CharmInfo, error) {
@@ -711,6 +750,9 @@ func (m *mockCharmClient) CharmInfo(curl string) (*charms.CharmInfo, error) {
 	if err := m.NextErr(); err != nil {
 		return nil, err
 	}
+	if info, ok := m.charmInfos[curl]; ok {
+		return info, nil
+	}
 	return m.charmInfo, nil
 }
 
@@ -81,3 +81,16 @@ func NewUpdateCredentialCommandForTest(testStore jujuclient.ClientStore, api cre
 	c.SetClientStore(testStore)
 	return modelcmd.WrapController(c)
 }
+
+func NewUploadCloudCommandForTest(
+	testStore jujuclient.ClientStore,
+	api uploadCloudAPI,
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error),
+) cmd.Command {
+	c := &uploadCloudCommand{
+		api:             api,
+		cloudByNameFunc: cloudByNameFunc,
+	}
+	c.SetClientStore(testStore)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	apicloud "github.com/juju/juju/api/cloud"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageUploadCloudSummary = `
+Adds a cloud definition to a controller.`[1:]
+
+var usageUploadCloudDetails = `
+Adds a cloud known to the client, such as one added with add-cloud, to
+a controller, so that models may be created in it. Only controller
+superusers may add clouds.
+
+Examples:
+    juju upload-cloud mycloud
+    juju upload-cloud -c mycontroller mycloud
+
+See also: 
+    add-cloud
+    clouds
+    add-model`[1:]
+
+type uploadCloudCommand struct {
+	modelcmd.ControllerCommandBase
+
+	api             uploadCloudAPI
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error)
+
+	cloud string
+}
+
+// NewUploadCloudCommand returns a command to add a cloud definition
+// to a controller.
+func NewUploadCloudCommand() cmd.Command {
+	return modelcmd.WrapController(&uploadCloudCommand{
+		cloudByNameFunc: common.CloudByName,
+	})
+}
+
+// Init implements Command.Init.
+func (c *uploadCloudCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("Usage: juju upload-cloud <cloud-name>")
+	}
+	c.cloud = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info
+func (c *uploadCloudCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upload-cloud",
+		Args:    "<cloud-name>",
+		Purpose: usageUploadCloudSummary,
+		Doc:     usageUploadCloudDetails,
+	}
+}
+
+type uploadCloudAPI interface {
+	AddCloud(cloud jujucloud.Cloud) error
+	Close() error
+}
+
+func (c *uploadCloudCommand) getAPI() (uploadCloudAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return apicloud.NewClient(api), nil
+}
+
+// Run implements Command.Run
+func (c *uploadCloudCommand) Run(ctx *cmd.Context) error {
+	cloud, err := c.cloudByNameFunc(c.cloud)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.AddCloud(*cloud); err != nil {
+		return errors.Annotatef(err, "adding cloud %q to controller %q", c.cloud, c.ControllerName())
+	}
+	ctx.Infof("Added cloud %q to controller %q.", c.cloud, c.ControllerName())
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type uploadCloudSuite struct {
+	testing.BaseSuite
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&uploadCloudSuite{})
+
+func (s *uploadCloudSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store = &jujuclienttesting.MemStore{
+		Controllers: map[string]jujuclient.ControllerDetails{
+			"controller": {},
+		},
+		CurrentControllerName: "controller",
+	}
+}
+
+func (s *uploadCloudSuite) cloudByName(name string) (*jujucloud.Cloud, error) {
+	if name != "mycloud" {
+		return nil, errors.NotFoundf("cloud %s", name)
+	}
+	return &jujucloud.Cloud{
+		Name:      "mycloud",
+		Type:      "openstack",
+		AuthTypes: []jujucloud.AuthType{jujucloud.UserPassAuthType},
+		Regions:   []jujucloud.Region{{Name: "london", Endpoint: "https://london.mycloud.com:35574/v3.0/"}},
+	}, nil
+}
+
+func (s *uploadCloudSuite) TestBadArgs(c *gc.C) {
+	cmd := cloud.NewUploadCloudCommandForTest(s.store, nil, s.cloudByName)
+	_, err := testing.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "Usage: juju upload-cloud <cloud-name>")
+	_, err = testing.RunCommand(c, cmd, "mycloud", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *uploadCloudSuite) TestUnknownCloud(c *gc.C) {
+	fake := &fakeUploadCloudAPI{}
+	cmd := cloud.NewUploadCloudCommandForTest(s.store, fake, s.cloudByName)
+	_, err := testing.RunCommand(c, cmd, "somecloud")
+	c.Assert(err, gc.ErrorMatches, "cloud somecloud not found")
+	c.Assert(fake.clouds, gc.HasLen, 0)
+}
+
+func (s *uploadCloudSuite) TestUpload(c *gc.C) {
+	fake := &fakeUploadCloudAPI{}
+	cmd := cloud.NewUploadCloudCommandForTest(s.store, fake, s.cloudByName)
+	ctx, err := testing.RunCommand(c, cmd, "mycloud")
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.Replace(testing.Stderr(ctx), "\n", "", -1)
+	c.Assert(output, gc.Equals, `Added cloud "mycloud" to controller "controller".`)
+	expected, err := s.cloudByName("mycloud")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.clouds, jc.DeepEquals, []jujucloud.Cloud{*expected})
+}
+
+func (s *uploadCloudSuite) TestUploadError(c *gc.C) {
+	fake := &fakeUploadCloudAPI{err: errors.AlreadyExistsf(`cloud "mycloud"`)}
+	cmd := cloud.NewUploadCloudCommandForTest(s.store, fake, s.cloudByName)
+	_, err := testing.RunCommand(c, cmd, "mycloud")
+	c.Assert(err, gc.ErrorMatches, `adding cloud "mycloud" to controller "controller": cloud "mycloud" already exists`)
+}
+
+type fakeUploadCloudAPI struct {
+	clouds []jujucloud.Cloud
+	err    error
+}
+
+func (f *fakeUploadCloudAPI) AddCloud(cloud jujucloud.Cloud) error {
+	f.clouds = append(f.clouds, cloud)
+	return f.err
+}
+
+func (*fakeUploadCloudAPI) Close() error {
+	return nil
+}
@@ -282,6 +282,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewRemoveRelationCommand())
 	r.Register(application.NewRemoveApplicationCommand())
 	r.Register(application.NewRemoveUnitCommand())
+	r.Register(application.NewScaleApplicationCommand())
 
 	// Reporting commands.
 	r.Register(status.NewStatusCommand())
@@ -333,6 +334,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewAddGroupCommand())
+	r.Register(user.NewAddUserToGroupCommand())
+	r.Register(user.NewRemoveUserFromGroupCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -352,6 +356,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportBundleCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -422,6 +427,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -442,6 +448,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(cloud.NewAddCredentialCommand())
 	r.Register(cloud.NewRemoveCredentialCommand())
 	r.Register(cloud.NewUpdateCredentialCommand())
+	r.Register(cloud.NewUploadCloudCommand())
 
 	// Juju GUI commands.
 	r.Register(gui.NewGUICommand())
@@ -390,6 +390,7 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-group",
 	"add-machine",
 	"add-model",
 	"add-relation",
@@ -399,10 +400,12 @@ var commandNames = []string{
 	"add-subnet",
 	"add-unit",
 	"add-user",
+	"add-user-to-group",
 	"agree",
 	"agreements",
 	"allocate",
 	"attach",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -432,6 +435,7 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"get-constraints",
 	"get-model-constraints",
@@ -486,6 +490,7 @@ var commandNames = []string{
 	"remove-storage",
 	"remove-unit",
 	"remove-user",
+	"remove-user-from-group",
 	"resolved",
 	"resources",
 	"restore-backup",
@@ -493,6 +498,7 @@ var commandNames = []string{
 	"revoke",
 	"run",
 	"run-action",
+	"scale-application",
 	"scp",
 	"set-budget",
 	"set-constraints",
@@ -531,6 +537,7 @@ var commandNames = []string{
 	"upgrade-gui",
 	"upgrade-juju",
 	"upload-backup",
+	"upload-cloud",
 	"users",
 	"version",
 	"whoami",
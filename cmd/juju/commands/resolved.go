@@ -4,13 +4,17 @@
 package commands
 
 import (
+	"sort"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/status"
 )
 
 func newResolvedCommand() cmd.Command {
@@ -20,24 +24,51 @@ func newResolvedCommand() cmd.Command {
 // resolvedCommand marks a unit in an error state as ready to continue.
 type resolvedCommand struct {
 	modelcmd.ModelCommandBase
-	UnitName string
-	NoRetry  bool
+	UnitName        string
+	ApplicationName string
+	NoRetry         bool
+	All             bool
 }
 
+const resolvedDoc = `
+Marks the errors of a unit as resolved, so that the unit agent re-executes
+the failed hook (or skips it when --no-retry is given).
+
+With --all, every unit of the given application that is in an error state
+is resolved.
+
+Examples:
+    juju resolved wordpress/0
+    juju resolved --no-retry wordpress/0
+    juju resolved --all wordpress
+`
+
 func (c *resolvedCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "resolved",
-		Args:    "<unit>",
+		Args:    "<unit> | --all <application>",
 		Purpose: "Marks unit errors resolved and re-executes failed hooks.",
+		Doc:     resolvedDoc,
 	}
 }
 
 func (c *resolvedCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.NoRetry, "no-retry", false, "Do not re-execute failed hooks on the unit")
+	f.BoolVar(&c.All, "all", false, "Resolve all units of the application that are in an error state")
 }
 
 func (c *resolvedCommand) Init(args []string) error {
+	if c.All {
+		if len(args) == 0 {
+			return errors.Errorf("no application specified")
+		}
+		c.ApplicationName = args[0]
+		if !names.IsValidApplication(c.ApplicationName) {
+			return errors.Errorf("invalid application name %q", c.ApplicationName)
+		}
+		return cmd.CheckEmpty(args[1:])
+	}
 	if len(args) > 0 {
 		c.UnitName = args[0]
 		if !names.IsValidUnit(c.UnitName) {
@@ -50,11 +81,62 @@ func (c *resolvedCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
-func (c *resolvedCommand) Run(_ *cmd.Context) error {
+func (c *resolvedCommand) Run(ctx *cmd.Context) error {
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Resolved(c.UnitName, c.NoRetry), block.BlockChange)
+	if !c.All {
+		return block.ProcessBlockedError(client.Resolved(c.UnitName, c.NoRetry), block.BlockChange)
+	}
+
+	fullStatus, err := client.Status([]string{c.ApplicationName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitNames := failedUnits(fullStatus, c.ApplicationName)
+	if len(unitNames) == 0 {
+		return errors.Errorf("no units of application %q are in an error state", c.ApplicationName)
+	}
+	anyFailed := false
+	for _, unitName := range unitNames {
+		if err := client.Resolved(unitName, c.NoRetry); err != nil {
+			if params.IsCodeOperationBlocked(err) {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+			anyFailed = true
+			ctx.Infof("resolving unit %s failed: %v", unitName, err)
+			continue
+		}
+		ctx.Infof("resolved unit %s", unitName)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// failedUnits returns the sorted names of the units, including
+// subordinate units, of the given application that are in an
+// error state.
+func failedUnits(fullStatus *params.FullStatus, applicationName string) []string {
+	var result []string
+	var collect func(units map[string]params.UnitStatus)
+	collect = func(units map[string]params.UnitStatus) {
+		for unitName, unit := range units {
+			application, err := names.UnitApplication(unitName)
+			if err == nil && application == applicationName &&
+				(unit.AgentStatus.Status == string(status.Error) ||
+					unit.WorkloadStatus.Status == string(status.Error)) {
+				result = append(result, unitName)
+			}
+			collect(unit.Subordinates)
+		}
+	}
+	for _, app := range fullStatus.Applications {
+		collect(app.Units)
+	}
+	sort.Strings(result)
+	return result
 }
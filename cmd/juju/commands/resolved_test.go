@@ -124,6 +124,50 @@ func (s *ResolvedSuite) TestResolved(c *gc.C) {
 	}
 }
 
+func (s *ResolvedSuite) TestResolvedAll(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, "-n", "3", ch, "dummy", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runResolved(c, []string{"--all", "dummy"})
+	c.Assert(err, gc.ErrorMatches, `no units of application "dummy" are in an error state`)
+
+	now := time.Now()
+	for _, name := range []string{"dummy/0", "dummy/2"} {
+		u, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.SetAgentStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: "lol borken",
+			Since:   &now,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err = runResolved(c, []string{"--all", "--no-retry", "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	for name, mode := range map[string]state.ResolvedMode{
+		"dummy/0": state.ResolvedNoHooks,
+		"dummy/1": state.ResolvedNone,
+		"dummy/2": state.ResolvedNoHooks,
+	} {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(unit.Resolved(), gc.Equals, mode, gc.Commentf("unit %s", name))
+	}
+}
+
+func (s *ResolvedSuite) TestResolvedAllInitErrors(c *gc.C) {
+	err := runResolved(c, []string{"--all"})
+	c.Assert(err, gc.ErrorMatches, `no application specified`)
+
+	err = runResolved(c, []string{"--all", "dummy/0"})
+	c.Assert(err, gc.ErrorMatches, `invalid application name "dummy/0"`)
+
+	err = runResolved(c, []string{"--all", "dummy", "wordpress"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+}
+
 func (s *ResolvedSuite) TestBlockResolved(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, "-n", "5", ch, "dummy", "--series", "quantal")
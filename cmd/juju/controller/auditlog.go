@@ -0,0 +1,222 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageAuditLogSummary = `
+Displays the requests recorded in the controller's audit log.`[1:]
+
+var usageAuditLogDetails = `
+When auditing is enabled for a controller (the "auditing-enabled"
+controller configuration setting), every API request made by a user is
+recorded along with the time, the address it came from and its
+arguments. This command shows the recorded requests, newest first.
+Only controller superusers may read the audit log.
+
+The --after and --before options accept either a time in RFC3339 format
+(e.g. 2017-03-01T12:00:00Z), a date (e.g. 2017-03-01) or a duration
+(e.g. 2h) meaning that long ago.
+
+Examples:
+    juju audit-log
+    juju audit-log --user bob --after 24h
+    juju audit-log --after 2017-03-01 --before 2017-03-02 --format yaml
+
+See also:
+    controller-config`[1:]
+
+// NewAuditLogCommand returns a command that displays the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// auditLogCommand displays the entries of the audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api   auditLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	user      string
+	afterArg  string
+	beforeArg string
+	limit     int
+	isoTime   bool
+
+	after  time.Time
+	before time.Time
+}
+
+// auditLogAPI defines the controller API methods that the audit-log
+// command uses.
+type auditLogAPI interface {
+	AuditLog(apicontroller.AuditLogFilter) ([]params.AuditLogEntry, error)
+	Close() error
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: usageAuditLogSummary,
+		Doc:     usageAuditLogDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.afterArg, "after", "", "Only show requests made at or after this time")
+	f.StringVar(&c.beforeArg, "before", "", "Only show requests made before this time")
+	f.IntVar(&c.limit, "n", 100, "Show at most this many of the most recent requests; 0 shows all")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.Errorf("invalid username: %q", c.user)
+	}
+	if c.limit < 0 {
+		return errors.Errorf("-n must not be negative, got %d", c.limit)
+	}
+	var err error
+	if c.after, err = c.parseTime(c.afterArg); err != nil {
+		return errors.Annotate(err, "invalid --after value")
+	}
+	if c.before, err = c.parseTime(c.beforeArg); err != nil {
+		return errors.Annotate(err, "invalid --before value")
+	}
+	if !c.after.IsZero() && !c.before.IsZero() && !c.after.Before(c.before) {
+		return errors.New("--after must be earlier than --before")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseTime parses a time given on the command line as a RFC3339
+// time, a date, or a duration before now.
+func (c *auditLogCommand) parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.UTC(), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return c.clock.Now().Add(-d).UTC(), nil
+	}
+	return time.Time{}, errors.Errorf("%q is not a time, date or duration", value)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.AuditLog(apicontroller.AuditLogFilter{
+		User:   c.user,
+		After:  c.after,
+		Before: c.before,
+		Limit:  c.limit,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit log entries to display.")
+		return nil
+	}
+	result := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = auditLogEntry{
+			Timestamp:     entry.Timestamp,
+			User:          originUser(entry.OriginName),
+			Model:         entry.ModelUUID,
+			RemoteAddress: entry.RemoteAddress,
+			Operation:     entry.Operation,
+			Data:          entry.Data,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// auditLogEntry holds an audit log entry for display.
+type auditLogEntry struct {
+	Timestamp     time.Time              `yaml:"timestamp" json:"timestamp"`
+	User          string                 `yaml:"user" json:"user"`
+	Model         string                 `yaml:"model-uuid" json:"model-uuid"`
+	RemoteAddress string                 `yaml:"remote-address" json:"remote-address"`
+	Operation     string                 `yaml:"operation" json:"operation"`
+	Data          map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+// originUser returns the name of the user recorded as the origin of an
+// audit log entry, or the origin itself if it is not a user tag.
+func originUser(origin string) string {
+	tag, err := names.ParseUserTag(origin)
+	if err != nil {
+		return origin
+	}
+	return tag.Id()
+}
+
+func (c *auditLogCommand) formatTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Address", "Model", "Operation")
+	for _, entry := range entries {
+		w.Println(
+			common.FormatTime(&entry.Timestamp, c.isoTime),
+			entry.User,
+			entry.RemoteAddress,
+			entry.Model,
+			entry.Operation,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *jujutesting.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+var auditLogNow = time.Date(2017, 3, 2, 12, 0, 0, 0, time.UTC)
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.clock = jujutesting.NewClock(auditLogNow)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			Timestamp:     auditLogNow.Add(-time.Hour),
+			ModelUUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			RemoteAddress: "10.0.0.1:1234",
+			OriginType:    "API request",
+			OriginName:    "user-bob",
+			Operation:     "ModelManager:v5 - ModifyModelAccess",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--user", "not/valid"},
+		err:  `invalid username: "not/valid"`,
+	}, {
+		args: []string{"-n", "-1"},
+		err:  `-n must not be negative, got -1`,
+	}, {
+		args: []string{"--after", "yesterday"},
+		err:  `invalid --after value: "yesterday" is not a time, date or duration`,
+	}, {
+		args: []string{"--before", "-2h"},
+		err:  `invalid --before value: "-2h" is not a time, date or duration`,
+	}, {
+		args: []string{"--after", "1h", "--before", "2h"},
+		err:  `--after must be earlier than --before`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+		err := testing.InitCommand(command, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *AuditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.run(c, "--user", "bob", "--after", "24h", "--before", "2017-03-02", "-n", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"AuditLog", []interface{}{apicontroller.AuditLogFilter{
+			User:   "bob",
+			After:  auditLogNow.Add(-24 * time.Hour),
+			Before: time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC),
+			Limit:  5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestDefaultLimit(c *gc.C) {
+	_, err := s.run(c, "--after", "2017-03-01T12:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AuditLog", apicontroller.AuditLogFilter{
+		After: time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+		Limit: 100,
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Time                  User  Address        Model                                 Operation\n"+
+		"2017-03-02 11:00:00Z  bob   10.0.0.1:1234  deadbeef-0bad-400d-8000-4b1d0d06f00d  ModelManager:v5 - ModifyModelAccess\n")
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- timestamp: 2017-03-02T11:00:00Z\n"+
+		"  user: bob\n"+
+		"  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d\n"+
+		"  remote-address: 10.0.0.1:1234\n"+
+		"  operation: ModelManager:v5 - ModifyModelAccess\n")
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No audit log entries to display.\n")
+}
+
+func (s *AuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	jujutesting.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) AuditLog(filter apicontroller.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "AuditLog", filter)
+	return f.entries, f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
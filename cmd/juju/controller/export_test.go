@@ -156,6 +156,14 @@ func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an audit-log command with the API
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api auditLogAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
@@ -48,9 +48,10 @@ type destroyCommand struct {
 	// sleepFunc is used when calling the timed function to get model status updates.
 	sleepFunc func(time.Duration)
 
-	envName   string
-	assumeYes bool
-	api       DestroyModelAPI
+	envName        string
+	assumeYes      bool
+	destroyStorage bool
+	api            DestroyModelAPI
 }
 
 var destroyDoc = `
@@ -60,10 +61,15 @@ there. Due to the irreversible nature of the command, it will prompt for
 confirmation (unless overridden with the '-y' option) before taking any
 action.
 
+If the model contains storage, it is only destroyed when the
+'--destroy-storage' option is given, in which case the storage is
+destroyed along with it.
+
 Examples:
 
     juju destroy-model test
     juju destroy-model -y mymodel
+    juju destroy-model --destroy-storage mymodel
 
 See also:
     destroy-controller
@@ -78,7 +84,7 @@ Continue [y/N]? `[1:]
 // API that the destroy command calls. It is exported for mocking in tests.
 type DestroyModelAPI interface {
 	Close() error
-	DestroyModel(names.ModelTag) error
+	DestroyModel(tag names.ModelTag, destroyStorage bool) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -97,6 +103,7 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
 	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.destroyStorage, "destroy-storage", false, "Destroy all storage instances in the model")
 }
 
 // Init implements Command.Init.
@@ -165,7 +172,7 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 
 	// Attempt to destroy the model.
 	ctx.Infof("Destroying model")
-	err = api.DestroyModel(names.NewModelTag(modelDetails.ModelUUID))
+	err = api.DestroyModel(names.NewModelTag(modelDetails.ModelUUID), c.destroyStorage)
 	if err != nil {
 		return c.handleError(errors.Annotate(err, "cannot destroy model"), modelName)
 	}
@@ -235,6 +242,9 @@ func (c *destroyCommand) handleError(err error, modelName string) error {
 	if params.IsCodeOperationBlocked(err) {
 		return block.ProcessBlockedError(err, block.BlockDestroy)
 	}
+	if params.IsCodeHasPersistentStorage(err) {
+		return errors.Errorf("%v\nUse --destroy-storage to destroy the storage along with the model.", err)
+	}
 	logger.Errorf(`failed to destroy model %q`, modelName)
 	return err
 }
@@ -40,11 +40,13 @@ type fakeAPI struct {
 	env             map[string]interface{}
 	statusCallCount int
 	modelInfoErr    []*params.Error
+	destroyStorage  bool
 }
 
 func (f *fakeAPI) Close() error { return nil }
 
-func (f *fakeAPI) DestroyModel(names.ModelTag) error {
+func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage bool) error {
+	f.destroyStorage = destroyStorage
 	return f.err
 }
 
@@ -156,6 +158,25 @@ func (s *DestroySuite) TestDestroyBlocks(c *gc.C) {
 	c.Assert(s.api.statusCallCount, gc.Equals, 1)
 }
 
+func (s *DestroySuite) TestDestroyDestroyStorage(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--destroy-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.destroyStorage, jc.IsTrue)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+}
+
+func (s *DestroySuite) TestDestroyWithStorage(c *gc.C) {
+	s.api.err = &params.Error{
+		Code:    params.CodeHasPersistentStorage,
+		Message: "model contains 1 persistent storage instance(s)",
+	}
+	_, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, gc.ErrorMatches, `cannot destroy model: model contains 1 storage instance\(s\)
+Use --destroy-storage to destroy the storage along with the model.`)
+	c.Assert(s.api.destroyStorage, jc.IsFalse)
+	checkModelExistsInStore(c, "test1:admin/test2", s.store)
+}
+
 func (s *DestroySuite) TestFailedDestroyModel(c *gc.C) {
 	s.api.err = errors.New("permission denied")
 	_, err := s.runDestroyCommand(c, "test1:test2", "-y")
@@ -56,6 +56,13 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.WrapController(cmd)
 }
 
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -99,6 +106,11 @@ func NewGrantCommandForTest(api GrantModelAPI, store jujuclient.ClientStore) (cm
 	return modelcmd.WrapController(cmd), &GrantCommand{cmd}
 }
 
+// SetUserAPI sets the API used to look up users for a dry run.
+func (c *GrantCommand) SetUserAPI(api UserInfoAPI) {
+	c.userAPI = api
+}
+
 // NewRevokeCommandForTest returns an revokeCommand with the api provided as specified.
 func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (cmd.Command, *RevokeCommand) {
 	cmd := &revokeCommand{
@@ -107,3 +119,8 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// SetUserAPI sets the API used to look up users for a dry run.
+func (c *RevokeCommand) SetUserAPI(api UserInfoAPI) {
+	c.userAPI = api
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportBundleCommand returns a fully constructed export-bundle command.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api ExportBundleAPI
+
+	Filename string
+}
+
+const exportBundleHelpDoc = `
+Writes the applications, machines and relations of the current model
+out as a bundle, in YAML form. The bundle is written to stdout unless
+a file is specified with --filename.
+
+Units placed in nested containers cannot be represented in a bundle;
+models containing such units cannot be exported.
+
+Examples:
+
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy
+    dump-model
+`
+
+// Info implements Command.
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: "Exports the current model as a bundle.",
+		Doc:     exportBundleHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Filename, "filename", "", "Write the bundle to this file instead of stdout")
+}
+
+// Init implements Command.
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ExportBundleAPI specifies the used function calls of the Bundle facade.
+type ExportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (ExportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ExportBundle()
+	if err != nil {
+		return err
+	}
+	if c.Filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, result)
+		return err
+	}
+	path := ctx.AbsPath(c.Filename)
+	if err := ioutil.WriteFile(path, []byte(result), 0644); err != nil {
+		return errors.Annotate(err, "writing bundle")
+	}
+	ctx.Infof("Bundle successfully exported to %s", path)
+	return nil
+}
@@ -4,9 +4,18 @@
 package model
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/usermanager"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/permission"
@@ -21,6 +30,13 @@ By default, the controller is the current controller.
 Users with read access are limited in what they can do with models:
 ` + "`juju models`, `juju machines`, and `juju status`" + `.
 
+A group of users, created with add-group, may be given in place of a
+user name as @<group name>. Groups can only be granted model access.
+
+With --applications, 'write' access is granted to just the named
+applications in a single model; users who do not already have access to
+the model are given 'read' access to it.
+
 Valid access levels for models are:
     read
     write
@@ -44,13 +60,38 @@ Grant user 'sam' 'read' access to models 'model1' and 'model2':
 
     juju grant sam read model1 model2
 
+Grant users 'joe' and 'sam' 'write' access to models 'model1' and 'model2':
+
+    juju grant joe,sam write model1 model2
+
 Grant user 'maria' 'add-model' access to the controller:
 
     juju grant maria add-model
 
+Grant user 'sam' 'read' access to model 'mymodel' for one day, after
+which the controller revokes it automatically:
+
+    juju grant --expires-in 24h sam read mymodel
+
+Grant the members of group 'devs' 'write' access to model 'mymodel':
+
+    juju grant @devs write mymodel
+
+Grant user 'joe' 'write' access to only the applications 'wordpress'
+and 'nginx' in model 'mymodel', and 'read' access to the rest of the
+model:
+
+    juju grant joe write mymodel --applications wordpress,nginx
+
+Show what granting 'write' access to model 'mymodel' would change for
+user 'joe', without changing anything:
+
+    juju grant --dry-run joe write mymodel
+
 See also: 
     revoke
-    add-user`
+    add-user
+    add-group`
 
 var usageRevokeSummary = `
 Revokes access from a Juju user for a model or controller.`[1:]
@@ -60,7 +101,8 @@ By default, the controller is the current controller.
 
 Revoking write access, from a user who has that permission, will leave
 that user with read access. Revoking read access, however, also revokes
-write access.
+write and admin access. Likewise, revoking login access from the
+controller also revokes add-model and superuser access.
 
 Examples:
 Revoke 'read' (and 'write') access from user 'joe' for model 'mymodel':
@@ -75,15 +117,37 @@ Revoke 'add-model' access from user 'maria' to the controller:
 
     juju revoke maria add-model
 
+Revoke 'write' access from the members of group 'devs' for model
+'mymodel':
+
+    juju revoke @devs write mymodel
+
+Revoke 'write' access from user 'joe' for the application 'wordpress'
+in model 'mymodel', leaving their access to the model unchanged:
+
+    juju revoke joe write mymodel --applications wordpress
+
 See also: 
     grant`[1:]
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User       string
+	Users      []string
 	ModelNames []string
 	Access     string
+	DryRun     bool
+
+	Applications []string
+
+	userAPI UserInfoAPI
+}
+
+// SetFlags implements cmd.Command.
+func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the access changes that would be made, without making them")
+	f.Var(cmd.NewStringsValue(nil, &c.Applications), "applications", "Change write access to only these applications in the model")
 }
 
 // Init implements cmd.Command.
@@ -96,26 +160,114 @@ func (c *accessCommand) Init(args []string) error {
 		return errors.New("no permission level specified")
 	}
 
-	c.User = args[0]
+	for _, user := range strings.Split(args[0], ",") {
+		if user == "" {
+			return errors.Errorf("invalid user list %q", args[0])
+		}
+		c.Users = append(c.Users, user)
+	}
 	c.ModelNames = args[2:]
 	c.Access = args[1]
 	// Special case for backwards compatibility.
 	if c.Access == "addmodel" {
 		c.Access = "add-model"
 	}
+	if len(c.Applications) > 0 {
+		return c.validateApplications()
+	}
 	if len(c.ModelNames) > 0 {
+		if c.DryRun && c.hasGroup() {
+			return errors.New("--dry-run is not supported for groups")
+		}
 		if err := permission.ValidateControllerAccess(permission.Access(c.Access)); err == nil {
 			return errors.Errorf("You have specified a controller access permission %q.\n"+
 				"If you intended to change controller access, do not specify any model names.\n"+
 				"See 'juju help grant'.", c.Access)
 		}
-		return permission.ValidateModelAccess(permission.Access(c.Access))
+		if err := permission.ValidateModelAccess(permission.Access(c.Access)); err != nil {
+			return errors.Errorf("invalid model access permission %q, valid permissions are: %s",
+				c.Access, strings.Join(validModelAccess, ", "))
+		}
+		return nil
+	}
+	if c.hasGroup() {
+		return errors.New("groups can only be granted model access")
 	}
 	if err := permission.ValidateModelAccess(permission.Access(c.Access)); err == nil {
 		return errors.Errorf("You have specified a model access permission %q.\n"+
 			"If you intended to change model access, you need to specify one or more model names.\n"+
 			"See 'juju help grant'.", c.Access)
 	}
+	if err := permission.ValidateControllerAccess(permission.Access(c.Access)); err != nil {
+		return errors.Errorf("invalid controller access permission %q, valid permissions are: %s",
+			c.Access, strings.Join(validControllerAccess, ", "))
+	}
+	return nil
+}
+
+// validateApplications checks the arguments given with --applications.
+func (c *accessCommand) validateApplications() error {
+	if len(c.ModelNames) != 1 {
+		return errors.New("--applications requires exactly one model name")
+	}
+	if c.Access != string(permission.WriteAccess) {
+		return errors.Errorf("only %q access may be changed on applications", permission.WriteAccess)
+	}
+	if c.hasGroup() {
+		return errors.New("--applications is not supported for groups")
+	}
+	if c.DryRun {
+		return errors.New("--dry-run is not supported with --applications")
+	}
+	for _, application := range c.Applications {
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("invalid application name %q", application)
+		}
+	}
+	return nil
+}
+
+// hasGroup reports whether any of the users is a group, given as
+// @<group name>.
+func (c *accessCommand) hasGroup() bool {
+	for _, user := range c.Users {
+		if strings.HasPrefix(user, "@") {
+			return true
+		}
+	}
+	return false
+}
+
+// validModelAccess and validControllerAccess list, from least to most
+// privileged, the permissions that may be granted or revoked.
+var (
+	validModelAccess = []string{
+		string(permission.ReadAccess),
+		string(permission.WriteAccess),
+		string(permission.AdminAccess),
+	}
+	validControllerAccess = []string{
+		string(permission.LoginAccess),
+		string(permission.AddModelAccess),
+		string(permission.SuperuserAccess),
+	}
+)
+
+// forEachUser calls f for each user, reporting the users for which it
+// failed in a single error.
+func (c *accessCommand) forEachUser(f func(user string) error) error {
+	if len(c.Users) == 1 {
+		return f(c.Users[0])
+	}
+	var failures []string
+	for _, user := range c.Users {
+		if err := f(user); err != nil {
+			failures = append(failures, fmt.Sprintf("user %q: %v", user, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
 	return nil
 }
 
@@ -127,14 +279,41 @@ func NewGrantCommand() cmd.Command {
 // grantCommand represents the command to grant a user access to one or more models.
 type grantCommand struct {
 	accessCommand
-	api GrantModelAPI
+	api       GrantModelAPI
+	ExpiresIn time.Duration
+}
+
+// SetFlags implements cmd.Command.
+func (c *grantCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.accessCommand.SetFlags(f)
+	f.DurationVar(&c.ExpiresIn, "expires-in", 0, "Revoke the granted model access automatically after this long")
+}
+
+// Init implements cmd.Command.
+func (c *grantCommand) Init(args []string) error {
+	if err := c.accessCommand.Init(args); err != nil {
+		return err
+	}
+	if c.ExpiresIn < 0 {
+		return errors.Errorf("--expires-in must be positive, got %v", c.ExpiresIn)
+	}
+	if c.ExpiresIn > 0 && len(c.ModelNames) == 0 {
+		return errors.New("--expires-in is only supported when granting model access")
+	}
+	if c.ExpiresIn > 0 && c.hasGroup() {
+		return errors.New("--expires-in is not supported for groups")
+	}
+	if c.ExpiresIn > 0 && len(c.Applications) > 0 {
+		return errors.New("--expires-in is not supported with --applications")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <permission> [<model name> ...]",
+		Args:    "<user name>[,<user name>...] <permission> [<model name> ...]",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -154,34 +333,43 @@ func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
-	GrantModel(user, access string, modelUUIDs ...string) error
+	ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error)
+	GrantModelUsers(users []string, access string, modelUUIDs ...string) error
+	GrantModelUsersUntil(users []string, access string, expires time.Time, modelUUIDs ...string) error
+	GrantApplications(users []string, modelUUID string, applications ...string) error
 }
 
 // GrantControllerAPI defines the API functions used by the grant command.
 type GrantControllerAPI interface {
 	Close() error
+	GetControllerAccess(user string) (permission.Access, error)
 	GrantController(user, access string) error
 }
 
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
 	if len(c.ModelNames) > 0 {
-		return c.runForModel()
+		return c.runForModel(ctx)
 	}
-	return c.runForController()
+	return c.runForController(ctx)
 }
 
-func (c *grantCommand) runForController() error {
+func (c *grantCommand) runForController(ctx *cmd.Context) error {
 	client, err := c.getControllerAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	return block.ProcessBlockedError(client.GrantController(c.User, c.Access), block.BlockChange)
+	if c.DryRun {
+		return c.dryRunForController(ctx, client, grantControllerAccess)
+	}
+	return c.forEachUser(func(user string) error {
+		return block.ProcessBlockedError(client.GrantController(user, c.Access), block.BlockChange)
+	})
 }
 
-func (c *grantCommand) runForModel() error {
+func (c *grantCommand) runForModel(ctx *cmd.Context) error {
 	client, err := c.getModelAPI()
 	if err != nil {
 		return err
@@ -192,7 +380,20 @@ func (c *grantCommand) runForModel() error {
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.GrantModel(c.User, c.Access, models...), block.BlockChange)
+	if len(c.Applications) > 0 {
+		err := client.GrantApplications(c.Users, models[0], c.Applications...)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.DryRun {
+		return c.dryRunForModels(ctx, client, models, grantModelAccess)
+	}
+	if c.ExpiresIn > 0 {
+		expires := time.Now().Add(c.ExpiresIn)
+		err = client.GrantModelUsersUntil(c.Users, c.Access, expires, models...)
+	} else {
+		err = client.GrantModelUsers(c.Users, c.Access, models...)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
 // NewRevokeCommand returns a new revoke command.
@@ -210,7 +411,7 @@ type revokeCommand struct {
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <permission> [<model name> ...]",
+		Args:    "<user>[,<user>...] <permission> [<model name> ...]",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -230,34 +431,42 @@ func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
-	RevokeModel(user, access string, modelUUIDs ...string) error
+	ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error)
+	RevokeModelUsers(users []string, access string, modelUUIDs ...string) error
+	RevokeApplications(users []string, modelUUID string, applications ...string) error
 }
 
 // RevokeControllerAPI defines the API functions used by the revoke command.
 type RevokeControllerAPI interface {
 	Close() error
+	GetControllerAccess(user string) (permission.Access, error)
 	RevokeController(user, access string) error
 }
 
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
 	if len(c.ModelNames) > 0 {
-		return c.runForModel()
+		return c.runForModel(ctx)
 	}
-	return c.runForController()
+	return c.runForController(ctx)
 }
 
-func (c *revokeCommand) runForController() error {
+func (c *revokeCommand) runForController(ctx *cmd.Context) error {
 	client, err := c.getControllerAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	return block.ProcessBlockedError(client.RevokeController(c.User, c.Access), block.BlockChange)
+	if c.DryRun {
+		return c.dryRunForController(ctx, client, revokeControllerAccess)
+	}
+	return c.forEachUser(func(user string) error {
+		return block.ProcessBlockedError(client.RevokeController(user, c.Access), block.BlockChange)
+	})
 }
 
-func (c *revokeCommand) runForModel() error {
+func (c *revokeCommand) runForModel(ctx *cmd.Context) error {
 	client, err := c.getModelAPI()
 	if err != nil {
 		return err
@@ -268,5 +477,220 @@ func (c *revokeCommand) runForModel() error {
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, models...), block.BlockChange)
+	if len(c.Applications) > 0 {
+		err := client.RevokeApplications(c.Users, models[0], c.Applications...)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.DryRun {
+		return c.dryRunForModels(ctx, client, models, revokeModelAccess)
+	}
+	return block.ProcessBlockedError(client.RevokeModelUsers(c.Users, c.Access, models...), block.BlockChange)
+}
+
+// accessTransition computes the access a user would be left with after
+// a grant or revoke, given their current access. It returns an error if
+// the change would be rejected.
+type accessTransition func(current, requested permission.Access) (permission.Access, error)
+
+func grantModelAccess(current, requested permission.Access) (permission.Access, error) {
+	if current.EqualOrGreaterModelAccessThan(requested) {
+		return current, errors.Errorf("user already has %q access or greater", requested)
+	}
+	return requested, nil
+}
+
+func revokeModelAccess(current, requested permission.Access) (permission.Access, error) {
+	if current == permission.NoAccess {
+		return current, errors.New("user has no access")
+	}
+	switch requested {
+	case permission.ReadAccess:
+		return permission.NoAccess, nil
+	case permission.WriteAccess:
+		return permission.ReadAccess, nil
+	case permission.AdminAccess:
+		return permission.WriteAccess, nil
+	}
+	return current, errors.Errorf("don't know how to revoke %q access", requested)
+}
+
+func grantControllerAccess(current, requested permission.Access) (permission.Access, error) {
+	if current.EqualOrGreaterControllerAccessThan(requested) {
+		return current, errors.Errorf("user already has %q access or greater", requested)
+	}
+	return requested, nil
+}
+
+func revokeControllerAccess(current, requested permission.Access) (permission.Access, error) {
+	if current == permission.NoAccess {
+		return current, errors.New("user has no access")
+	}
+	switch requested {
+	case permission.LoginAccess:
+		return permission.NoAccess, nil
+	case permission.AddModelAccess:
+		return permission.LoginAccess, nil
+	case permission.SuperuserAccess:
+		return permission.AddModelAccess, nil
+	}
+	return current, errors.Errorf("don't know how to revoke %q access", requested)
+}
+
+// modelInfoAPI defines the API functions used to look up current
+// model access for a dry run.
+type modelInfoAPI interface {
+	ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error)
+}
+
+// UserInfoAPI defines the API functions used to check that users exist
+// for a dry run.
+type UserInfoAPI interface {
+	Close() error
+	UserInfo(usernames []string, all usermanager.IncludeDisabled) ([]params.UserInfo, error)
+}
+
+func (c *accessCommand) getUserAPI() (UserInfoAPI, error) {
+	if c.userAPI != nil {
+		return c.userAPI, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// unknownUsers returns those of the users that the controller does not
+// know about. External users are never reported, as they are not
+// recorded by the controller until they are granted access.
+func (c *accessCommand) unknownUsers() (set.Strings, error) {
+	client, err := c.getUserAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	unknown := set.NewStrings()
+	for _, user := range c.Users {
+		if !names.IsValidUser(user) {
+			return nil, errors.Errorf("invalid username: %q", user)
+		}
+		if !names.NewUserTag(user).IsLocal() {
+			continue
+		}
+		_, err := client.UserInfo([]string{user}, usermanager.AllUsers)
+		switch {
+		case err == nil:
+		case params.IsCodeNotFound(err):
+			unknown.Add(user)
+		case params.IsCodeUnauthorized(err):
+			// Only controller administrators may look up other
+			// users, so assume that the user exists.
+		default:
+			return nil, errors.Annotatef(err, "user %q", user)
+		}
+	}
+	return unknown, nil
+}
+
+// controllerAccessAPI defines the API functions used to look up current
+// controller access for a dry run.
+type controllerAccessAPI interface {
+	GetControllerAccess(user string) (permission.Access, error)
+}
+
+// dryRunForModels prints the change in access each user would see on
+// each of the models, without making any changes.
+func (c *accessCommand) dryRunForModels(ctx *cmd.Context, client modelInfoAPI, modelUUIDs []string, transition accessTransition) error {
+	tags := make([]names.ModelTag, len(modelUUIDs))
+	for i, uuid := range modelUUIDs {
+		tags[i] = names.NewModelTag(uuid)
+	}
+	results, err := client.ModelInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != len(tags) {
+		return errors.Errorf("expected %d results, got %d", len(tags), len(results))
+	}
+	unknown, err := c.unknownUsers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyRejected := false
+	for i, result := range results {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "model %q", c.ModelNames[i])
+		}
+		for _, user := range c.Users {
+			if unknown.Contains(user) {
+				printUnknownUser(ctx, "model "+c.ModelNames[i], user)
+				anyRejected = true
+				continue
+			}
+			current := permission.NoAccess
+			userName := names.NewUserTag(user).Id()
+			for _, modelUser := range result.Result.Users {
+				if modelUser.UserName == userName {
+					current = permission.Access(modelUser.Access)
+				}
+			}
+			if !c.printTransition(ctx, "model "+c.ModelNames[i], user, current, transition) {
+				anyRejected = true
+			}
+		}
+	}
+	if anyRejected {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// dryRunForController prints the change in controller access each user
+// would see, without making any changes.
+func (c *accessCommand) dryRunForController(ctx *cmd.Context, client controllerAccessAPI, transition accessTransition) error {
+	unknown, err := c.unknownUsers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyRejected := false
+	for _, user := range c.Users {
+		if unknown.Contains(user) {
+			printUnknownUser(ctx, "controller", user)
+			anyRejected = true
+			continue
+		}
+		current, err := client.GetControllerAccess(user)
+		if err != nil {
+			return errors.Annotatef(err, "user %q", user)
+		}
+		if !c.printTransition(ctx, "controller", user, current, transition) {
+			anyRejected = true
+		}
+	}
+	if anyRejected {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// printTransition prints the access change for a user on the target,
+// and reports whether the change would be accepted.
+func (c *accessCommand) printTransition(ctx *cmd.Context, target, user string, current permission.Access, transition accessTransition) bool {
+	next, err := transition(current, permission.Access(c.Access))
+	if err != nil {
+		fmt.Fprintf(ctx.Stdout, "%s on %s: %s (rejected: %v)\n", user, target, accessString(current), err)
+		return false
+	}
+	fmt.Fprintf(ctx.Stdout, "%s on %s: %s -> %s\n", user, target, accessString(current), accessString(next))
+	return true
+}
+
+// printUnknownUser prints that a change for the user on the target
+// would be rejected because the user does not exist.
+func printUnknownUser(ctx *cmd.Context, target, user string) {
+	fmt.Fprintf(ctx.Stdout, "%s on %s: user not found\n", user, target)
+}
+
+func accessString(access permission.Access) string {
+	if access == permission.NoAccess {
+		return "none"
+	}
+	return string(access)
 }
@@ -4,13 +4,19 @@
 package model_test
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -65,24 +71,90 @@ func (s *grantRevokeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
 }
 
 func (s *grantRevokeSuite) TestPassesValues(c *gc.C) {
-	user := "sam"
 	models := []string{fooModelUUID, barModelUUID, bazModelUUID}
 	_, err := s.run(c, "sam", "read", "foo", "bar", "baz")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(s.fake.user, jc.DeepEquals, user)
+	c.Assert(s.fake.users, jc.DeepEquals, []string{"sam"})
 	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, models)
 	c.Assert(s.fake.access, gc.Equals, "read")
 }
 
 func (s *grantRevokeSuite) TestAccess(c *gc.C) {
-	sam := "sam"
 	_, err := s.run(c, "sam", "write", "model1", "model2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(s.fake.user, jc.DeepEquals, sam)
+	c.Assert(s.fake.users, jc.DeepEquals, []string{"sam"})
 	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID, model2ModelUUID})
 	c.Assert(s.fake.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestMultipleUsers(c *gc.C) {
+	_, err := s.run(c, "joe,sam", "write", "model1", "model2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.users, jc.DeepEquals, []string{"joe", "sam"})
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID, model2ModelUUID})
+	c.Assert(s.fake.access, gc.Equals, "write")
+}
+
+func (s *grantRevokeSuite) TestInvalidUserList(c *gc.C) {
+	_, err := s.run(c, "joe,,sam", "write", "model1")
+	c.Assert(err, gc.ErrorMatches, `invalid user list "joe,,sam"`)
+}
+
+func (s *grantRevokeSuite) TestGroup(c *gc.C) {
+	_, err := s.run(c, "@devs", "write", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.users, jc.DeepEquals, []string{"@devs"})
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID})
+	c.Assert(s.fake.access, gc.Equals, "write")
+}
+
+func (s *grantRevokeSuite) TestGroupControllerAccess(c *gc.C) {
+	_, err := s.run(c, "@devs", "add-model")
+	c.Assert(err, gc.ErrorMatches, "groups can only be granted model access")
+}
+
+func (s *grantRevokeSuite) TestGroupDryRun(c *gc.C) {
+	_, err := s.run(c, "--dry-run", "@devs", "write", "model1")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported for groups")
+}
+
+func (s *grantRevokeSuite) TestApplications(c *gc.C) {
+	_, err := s.run(c, "joe,sam", "write", "model1", "--applications", "wordpress,nginx")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.users, jc.DeepEquals, []string{"joe", "sam"})
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID})
+	c.Assert(s.fake.applications, jc.DeepEquals, []string{"wordpress", "nginx"})
+}
+
+func (s *grantRevokeSuite) TestApplicationsInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"joe", "write", "--applications", "wordpress"},
+		err:  "--applications requires exactly one model name",
+	}, {
+		args: []string{"joe", "write", "model1", "model2", "--applications", "wordpress"},
+		err:  "--applications requires exactly one model name",
+	}, {
+		args: []string{"joe", "admin", "model1", "--applications", "wordpress"},
+		err:  `only "write" access may be changed on applications`,
+	}, {
+		args: []string{"@devs", "write", "model1", "--applications", "wordpress"},
+		err:  "--applications is not supported for groups",
+	}, {
+		args: []string{"--dry-run", "joe", "write", "model1", "--applications", "wordpress"},
+		err:  "--dry-run is not supported with --applications",
+	}, {
+		args: []string{"joe", "write", "model1", "--applications", "Word_Press"},
+		err:  `invalid application name "Word_Press"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
 func (s *grantRevokeSuite) TestBlockGrant(c *gc.C) {
 	s.fake.err = common.OperationBlockedError("TestBlockGrant")
 	_, err := s.run(c, "sam", "read", "foo")
@@ -98,11 +170,75 @@ var _ = gc.Suite(&grantSuite{})
 func (s *grantSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewGrantCommandForTest(fake, s.store)
+		c, grantCmd := model.NewGrantCommandForTest(fake, s.store)
+		grantCmd.SetUserAPI(fake)
 		return c
 	}
 }
 
+func (s *grantSuite) TestDryRun(c *gc.C) {
+	s.fake.modelUsers = map[string][]params.ModelUserInfo{
+		model1ModelUUID: {{UserName: "joe", Access: "read"}},
+		model2ModelUUID: {{UserName: "joe", Access: "admin"}},
+	}
+	ctx, err := s.run(c, "--dry-run", "joe,sam", "write", "model1", "model2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.fake.modified, jc.IsFalse)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"joe on model model1: read -> write\n"+
+		"sam on model model1: none -> write\n"+
+		"joe on model model2: admin (rejected: user already has \"write\" access or greater)\n"+
+		"sam on model model2: none -> write\n")
+}
+
+func (s *grantSuite) TestDryRunUnknownUser(c *gc.C) {
+	s.fake.unknownUsers = []string{"nobody"}
+	ctx, err := s.run(c, "--dry-run", "sam,nobody,bob@external", "write", "model1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.fake.modified, jc.IsFalse)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"sam on model model1: none -> write\n"+
+		"nobody on model model1: user not found\n"+
+		"bob@external on model model1: none -> write\n")
+}
+
+func (s *grantSuite) TestExpiresIn(c *gc.C) {
+	before := time.Now()
+	_, err := s.run(c, "--expires-in", "2h", "sam", "read", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.users, jc.DeepEquals, []string{"sam"})
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID})
+	c.Assert(s.fake.expires, gc.NotNil)
+	c.Assert(s.fake.expires.Before(before.Add(2*time.Hour)), jc.IsFalse)
+	c.Assert(s.fake.expires.After(time.Now().Add(2*time.Hour)), jc.IsFalse)
+}
+
+func (s *grantSuite) TestNoExpiry(c *gc.C) {
+	_, err := s.run(c, "sam", "read", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.expires, gc.IsNil)
+}
+
+func (s *grantSuite) TestExpiresInInitErrors(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"--expires-in", "-1h", "sam", "read", "model1"})
+	c.Assert(err, gc.ErrorMatches, `--expires-in must be positive, got -1h0m0s`)
+
+	wrappedCmd, _ = model.NewGrantCommandForTest(s.fake, s.store)
+	err = testing.InitCommand(wrappedCmd, []string{"--expires-in", "1h", "sam", "login"})
+	c.Assert(err, gc.ErrorMatches, `--expires-in is only supported when granting model access`)
+}
+
+func (s *grantSuite) TestApplicationsExpiresIn(c *gc.C) {
+	_, err := s.run(c, "--expires-in", "1h", "joe", "write", "model1", "--applications", "wordpress")
+	c.Assert(err, gc.ErrorMatches, "--expires-in is not supported with --applications")
+}
+
+func (s *grantSuite) TestGroupExpiresIn(c *gc.C) {
+	_, err := s.run(c, "--expires-in", "1h", "@devs", "read", "model1")
+	c.Assert(err, gc.ErrorMatches, "--expires-in is not supported for groups")
+}
+
 func (s *grantSuite) TestInit(c *gc.C) {
 	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
@@ -111,7 +247,7 @@ func (s *grantSuite) TestInit(c *gc.C) {
 	err = testing.InitCommand(wrappedCmd, []string{"bob", "read", "model1", "model2"})
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Assert(grantCmd.User, gc.Equals, "bob")
+	c.Assert(grantCmd.Users, jc.DeepEquals, []string{"bob"})
 	c.Assert(grantCmd.ModelNames, jc.DeepEquals, []string{"model1", "model2"})
 
 	err = testing.InitCommand(wrappedCmd, []string{})
@@ -141,11 +277,39 @@ var _ = gc.Suite(&revokeSuite{})
 func (s *revokeSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewRevokeCommandForTest(fake, s.store)
+		c, revokeCmd := model.NewRevokeCommandForTest(fake, s.store)
+		revokeCmd.SetUserAPI(fake)
 		return c
 	}
 }
 
+func (s *revokeSuite) TestDryRun(c *gc.C) {
+	s.fake.modelUsers = map[string][]params.ModelUserInfo{
+		model1ModelUUID: {{UserName: "joe", Access: "admin"}, {UserName: "sam", Access: "write"}},
+	}
+	ctx, err := s.run(c, "--dry-run", "joe,sam", "admin", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.modified, jc.IsFalse)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"joe on model model1: admin -> write\n"+
+		"sam on model model1: write -> write\n")
+}
+
+func (s *revokeSuite) TestDryRunNoAccess(c *gc.C) {
+	ctx, err := s.run(c, "--dry-run", "joe", "read", "model1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.fake.modified, jc.IsFalse)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "joe on model model1: none (rejected: user has no access)\n")
+}
+
+func (s *revokeSuite) TestDryRunUnknownUser(c *gc.C) {
+	s.fake.unknownUsers = []string{"nobody"}
+	ctx, err := s.run(c, "--dry-run", "nobody", "read", "model1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.fake.modified, jc.IsFalse)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "nobody on model model1: user not found\n")
+}
+
 func (s *revokeSuite) TestInit(c *gc.C) {
 	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
@@ -154,7 +318,7 @@ func (s *revokeSuite) TestInit(c *gc.C) {
 	err = testing.InitCommand(wrappedCmd, []string{"bob", "read", "model1", "model2"})
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Assert(revokeCmd.User, gc.Equals, "bob")
+	c.Assert(revokeCmd.Users, jc.DeepEquals, []string{"bob"})
 	c.Assert(revokeCmd.ModelNames, jc.DeepEquals, []string{"model1", "model2"})
 
 	err = testing.InitCommand(wrappedCmd, []string{})
@@ -190,26 +354,87 @@ func (s *grantSuite) TestControllerAccessForModel(c *gc.C) {
 	c.Check(msg, gc.Matches, `You have specified a controller access permission "superuser".*`)
 }
 
+func (s *grantSuite) TestInvalidModelAccess(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "owner", "default"})
+	c.Check(err, gc.ErrorMatches, `invalid model access permission "owner", valid permissions are: read, write, admin`)
+}
+
+func (s *grantSuite) TestInvalidControllerAccess(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "owner"})
+	c.Check(err, gc.ErrorMatches, `invalid controller access permission "owner", valid permissions are: login, add-model, superuser`)
+}
+
 type fakeGrantRevokeAPI struct {
 	err        error
-	user       string
+	users      []string
 	access     string
 	modelUUIDs []string
+	modelUsers map[string][]params.ModelUserInfo
+	modified   bool
+	expires    *time.Time
+
+	applications []string
+	unknownUsers []string
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
 
-func (f *fakeGrantRevokeAPI) GrantModel(user, access string, modelUUIDs ...string) error {
-	return f.fake(user, access, modelUUIDs...)
+func (f *fakeGrantRevokeAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
+	results := make([]params.ModelInfoResult, len(tags))
+	for i, tag := range tags {
+		results[i].Result = &params.ModelInfo{
+			UUID:  tag.Id(),
+			Users: f.modelUsers[tag.Id()],
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeGrantRevokeAPI) UserInfo(usernames []string, all usermanager.IncludeDisabled) ([]params.UserInfo, error) {
+	var result []params.UserInfo
+	for _, name := range usernames {
+		for _, unknown := range f.unknownUsers {
+			if name == unknown {
+				return nil, errors.Annotate(&params.Error{
+					Code:    params.CodeNotFound,
+					Message: fmt.Sprintf("user %q not found", name),
+				}, name)
+			}
+		}
+		result = append(result, params.UserInfo{Username: name})
+	}
+	return result, nil
+}
+
+func (f *fakeGrantRevokeAPI) GrantModelUsers(users []string, access string, modelUUIDs ...string) error {
+	return f.fake(users, access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) GrantModelUsersUntil(users []string, access string, expires time.Time, modelUUIDs ...string) error {
+	f.expires = &expires
+	return f.fake(users, access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeModelUsers(users []string, access string, modelUUIDs ...string) error {
+	return f.fake(users, access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) GrantApplications(users []string, modelUUID string, applications ...string) error {
+	f.applications = applications
+	return f.fake(users, "write", modelUUID)
 }
 
-func (f *fakeGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ...string) error {
-	return f.fake(user, access, modelUUIDs...)
+func (f *fakeGrantRevokeAPI) RevokeApplications(users []string, modelUUID string, applications ...string) error {
+	f.applications = applications
+	return f.fake(users, "write", modelUUID)
 }
 
-func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
-	f.user = user
+func (f *fakeGrantRevokeAPI) fake(users []string, access string, modelUUIDs ...string) error {
+	f.users = users
 	f.access = access
 	f.modelUUIDs = modelUUIDs
+	f.modified = true
 	return f.err
 }
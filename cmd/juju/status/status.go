@@ -8,28 +8,43 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/state/multiwatcher"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	WatchAll() (*api.AllWatcher, error)
 	Close() error
 }
 
+// allWatcher is the subset of api.AllWatcher used to learn when the
+// model changes.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
-	return modelcmd.Wrap(&statusCommand{})
+	return modelcmd.Wrap(&statusCommand{
+		clock:         clock.WallClock,
+		newAllWatcher: newAllWatcher,
+	})
 }
 
 type statusCommand struct {
@@ -38,8 +53,14 @@ type statusCommand struct {
 	patterns []string
 	isoTime  bool
 	api      statusAPI
+	clock    clock.Clock
+
+	// newAllWatcher returns the watcher used by --watch to learn
+	// when the model changes.
+	newAllWatcher func(statusAPI) (allWatcher, error)
 
 	color bool
+	watch time.Duration
 }
 
 var usageSummary = `
@@ -73,10 +94,14 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --watch, the status is displayed again whenever the model changes,
+at most once per interval, until the command is interrupted.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --watch 5s
 
 See also:
     machines
@@ -99,6 +124,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.DurationVar(&c.watch, "watch", 0, "Redisplay the status when the model changes, at most once per interval (e.g. 5s)")
 
 	defaultFormat := "tabular"
 
@@ -115,6 +141,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch < 0 {
+		return errors.Errorf("--watch interval must be positive, got %v", c.watch)
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -133,6 +162,14 @@ var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	return c.NewAPIClient()
 }
 
+func newAllWatcher(apiclient statusAPI) (allWatcher, error) {
+	watcher, err := apiclient.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	apiclient, err := newAPIClientForStatus(c)
 	if err != nil {
@@ -140,6 +177,64 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.watch == 0 {
+		return c.runStatus(ctx, apiclient)
+	}
+	return c.watchStatus(ctx, apiclient)
+}
+
+// watchStatus displays the status whenever the all watcher reports a
+// change to the model, waiting at least c.watch between displays so
+// that bursts of changes are coalesced. The watcher's first event is
+// the initial state of the model, so the status is displayed at once.
+func (c *statusCommand) watchStatus(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := c.newAllWatcher(apiclient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	defer watcher.Stop()
+
+	changes := make(chan error)
+	go func() {
+		for {
+			_, err := watcher.Next()
+			select {
+			case changes <- err:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-changes:
+			if err != nil {
+				return errors.Annotate(err, "watching status")
+			}
+		}
+		if err := c.runStatus(ctx, apiclient); err != nil {
+			return err
+		}
+		select {
+		case <-interrupted:
+			return nil
+		case <-c.clock.After(c.watch):
+		}
+	}
+}
+
+func (c *statusCommand) runStatus(ctx *cmd.Context, apiclient statusAPI) error {
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
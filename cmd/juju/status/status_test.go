@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -22,6 +24,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
@@ -3731,6 +3734,10 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) WatchAll() (*api.AllWatcher, error) {
+	return nil, errors.NotSupportedf("watching the fake API client")
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -4099,6 +4106,99 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "error: unable to obtain the current status\n")
 }
 
+type fakeAllWatcher struct {
+	changes chan []multiwatcher.Delta
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	deltas, ok := <-w.changes
+	if !ok {
+		return nil, errors.New("watcher stopped")
+	}
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	return nil
+}
+
+type countingAPIClient struct {
+	fakeAPIClient
+	called chan struct{}
+}
+
+func (a *countingAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.called <- struct{}{}
+	return a.fakeAPIClient.Status(patterns)
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	client := &countingAPIClient{
+		fakeAPIClient: fakeAPIClient{
+			statusReturn: &params.FullStatus{Model: params.ModelStatusInfo{Name: "controller"}},
+		},
+		called: make(chan struct{}),
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	watcher := &fakeAllWatcher{changes: make(chan []multiwatcher.Delta)}
+	clock := jujutesting.NewClock(time.Time{})
+
+	ctx := coretesting.Context(c)
+	result := make(chan int)
+	go func() {
+		command := modelcmd.Wrap(&statusCommand{
+			clock: clock,
+			newAllWatcher: func(statusAPI) (allWatcher, error) {
+				return watcher, nil
+			},
+		})
+		result <- cmd.Main(command, ctx, []string{"--format", "yaml", "--watch", "5s"})
+	}()
+
+	assertCalled := func() {
+		select {
+		case <-client.called:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("status not displayed")
+		}
+	}
+	assertNotCalled := func() {
+		select {
+		case <-client.called:
+			c.Fatalf("status displayed unexpectedly")
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+
+	// The initial event displays the status.
+	watcher.changes <- nil
+	assertCalled()
+
+	// A change is not displayed until the interval has passed.
+	watcher.changes <- nil
+	assertNotCalled()
+	err := clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCalled()
+
+	// Without a change, the interval passing displays nothing.
+	err = clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotCalled()
+
+	close(watcher.changes)
+	select {
+	case code := <-result:
+		c.Check(code, gc.Equals, 1)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("command did not finish")
+	}
+	c.Check(ctx.Stderr.(*bytes.Buffer).String(), gc.Equals, "error: watching status: watcher stopped\n")
+	c.Check(strings.Count(ctx.Stdout.(*bytes.Buffer).String(), "model:"), gc.Equals, 2)
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -4550,6 +4650,11 @@ var statusInitTests = []struct {
 	}, {
 		envVar: "foo",
 		err:    "invalid JUJU_STATUS_ISO_TIME env var, expected true|false.*",
+	}, {
+		args: []string{"--watch", "5s"},
+	}, {
+		args: []string{"--watch", "-5s"},
+		err:  "--watch interval must be positive, got -5s",
 	},
 }
 
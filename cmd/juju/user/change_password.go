@@ -7,10 +7,13 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
@@ -30,10 +33,17 @@ the ` + "`juju show-user`" + ` command.
 A controller administrator can change the password for another user (on
 that controller).
 
+The new password is prompted for, unless the --file option names a file
+containing it. Only the first line of the file is used. The controller
+may require passwords to be of a minimum length or to contain a mix of
+character types; see the password-min-length and
+password-min-character-classes controller settings.
+
 Examples:
 
     juju change-user-password
     juju change-user-password bob
+    juju change-user-password bob --file bob-password.txt
 
 See also:
     add-user
@@ -52,6 +62,7 @@ type changePasswordCommand struct {
 	newAPIConnection func(juju.NewAPIConnectionParams) (api.Connection, error)
 	api              ChangePasswordAPI
 	User             string
+	PasswordFile     string
 }
 
 // Info implements Command.Info.
@@ -64,6 +75,12 @@ func (c *changePasswordCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *changePasswordCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.PasswordFile, "file", "", "Read the new password from this file instead of prompting for it")
+}
+
 // Init implements Command.Init.
 func (c *changePasswordCommand) Init(args []string) error {
 	var err error
@@ -92,7 +109,13 @@ func (c *changePasswordCommand) Run(ctx *cmd.Context) error {
 		defer c.api.Close()
 	}
 
-	newPassword, err := readAndConfirmPassword(ctx)
+	var newPassword string
+	var err error
+	if c.PasswordFile != "" {
+		newPassword, err = readPasswordFile(ctx.AbsPath(c.PasswordFile))
+	} else {
+		newPassword, err = readAndConfirmPassword(ctx)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -202,6 +225,19 @@ func readAndConfirmPassword(ctx *cmd.Context) (string, error) {
 	return password, nil
 }
 
+// readPasswordFile returns the first line of the named file.
+func readPasswordFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Annotate(err, "reading password file")
+	}
+	password := strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r")
+	if password == "" {
+		return "", errors.Errorf("password file %q is empty", path)
+	}
+	return password, nil
+}
+
 func readPassword(stdin io.Reader) (string, error) {
 	if f, ok := stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
 		password, err := terminal.ReadPassword(int(f.Fd()))
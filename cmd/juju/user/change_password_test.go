@@ -4,6 +4,8 @@
 package user_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -116,6 +118,27 @@ func (s *ChangePasswordCommandSuite) TestChangeOthersPassword(c *gc.C) {
 	s.assertAPICalls(c, "other", "sekrit")
 }
 
+func (s *ChangePasswordCommandSuite) TestChangePasswordFromFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "password")
+	err := ioutil.WriteFile(path, []byte("from-file\nignored\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, _, err := s.run(c, "--file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAPICalls(c, "current-user", "from-file")
+	c.Assert(coretesting.Stderr(context), gc.Equals, "Your password has been updated.\n")
+}
+
+func (s *ChangePasswordCommandSuite) TestChangePasswordFromEmptyFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "password")
+	err := ioutil.WriteFile(path, []byte("\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.run(c, "--file", path)
+	c.Assert(err, gc.ErrorMatches, `password file ".*" is empty`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
 type mockChangePasswordAPI struct {
 	testing.Stub
 }
@@ -16,6 +16,9 @@ Disables a Juju user.`[1:]
 
 var usageDisableUserDetails = `
 A disabled Juju user is one that cannot log in to any controller.
+Connections the user already has open are refused any further requests.
+The user's model and controller access is kept, and applies again once
+the user is re-enabled.
 This command has no affect on models that the disabled user may have
 created and/or shared nor any applications associated with that user.
 
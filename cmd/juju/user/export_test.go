@@ -124,3 +124,27 @@ func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
 	return c
 }
+
+// NewAddGroupCommandForTest returns an add-group command with the api
+// provided as specified.
+func NewAddGroupCommandForTest(api AddGroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addGroupCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddUserToGroupCommandForTest returns an add-user-to-group command
+// with the api provided as specified.
+func NewAddUserToGroupCommandForTest(api GroupMembersAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addUserToGroupCommand{groupMembersBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveUserFromGroupCommandForTest returns a remove-user-from-group
+// command with the api provided as specified.
+func NewRemoveUserFromGroupCommandForTest(api GroupMembersAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeUserFromGroupCommand{groupMembersBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
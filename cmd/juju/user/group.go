@@ -0,0 +1,235 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAddGroupSummary = `
+Adds a group of Juju users.`[1:]
+
+var usageAddGroupDetails = `
+A group collects users so that model access can be granted to all of
+them at once. Refer to a group as @<group name> when granting or
+revoking access. New groups have no members.
+
+Examples:
+    juju add-group devs
+    juju add-user-to-group devs bob,mary
+    juju grant @devs write mymodel
+
+See also:
+    add-user-to-group
+    remove-user-from-group
+    grant
+    revoke`[1:]
+
+var usageAddUserToGroupSummary = `
+Adds Juju users to a group.`[1:]
+
+var usageAddUserToGroupDetails = `
+Members of a group get the model access granted to the group, in
+addition to any access granted to them directly.
+
+Examples:
+    juju add-user-to-group devs bob
+    juju add-user-to-group devs bob,mary
+
+See also:
+    add-group
+    remove-user-from-group`[1:]
+
+var usageRemoveUserFromGroupSummary = `
+Removes Juju users from a group.`[1:]
+
+var usageRemoveUserFromGroupDetails = `
+Removed users lose the model access granted to the group, but keep any
+access granted to them directly.
+
+Examples:
+    juju remove-user-from-group devs bob
+
+See also:
+    add-group
+    add-user-to-group`[1:]
+
+// AddGroupAPI defines the usermanager API methods that the add-group
+// command uses.
+type AddGroupAPI interface {
+	AddGroup(name string) error
+	Close() error
+}
+
+// NewAddGroupCommand returns a command to add a group.
+func NewAddGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addGroupCommand{})
+}
+
+// addGroupCommand adds a group of users.
+type addGroupCommand struct {
+	modelcmd.ControllerCommandBase
+	api   AddGroupAPI
+	Group string
+}
+
+// Info implements Command.Info.
+func (c *addGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-group",
+		Args:    "<group name>",
+		Purpose: usageAddGroupSummary,
+		Doc:     usageAddGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *addGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *addGroupCommand) Run(ctx *cmd.Context) error {
+	if c.api == nil {
+		api, err := c.NewUserManagerAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.api = api
+		defer c.api.Close()
+	}
+
+	if err := c.api.AddGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q added", c.Group)
+	return nil
+}
+
+// GroupMembersAPI defines the usermanager API methods that the
+// add-user-to-group and remove-user-from-group commands use.
+type GroupMembersAPI interface {
+	AddGroupMembers(group string, usernames ...string) error
+	RemoveGroupMembers(group string, usernames ...string) error
+	Close() error
+}
+
+// groupMembersBase holds the code common to the commands that change
+// the members of a group.
+type groupMembersBase struct {
+	modelcmd.ControllerCommandBase
+	api   GroupMembersAPI
+	Group string
+	Users []string
+}
+
+// Init implements Command.Init.
+func (c *groupMembersBase) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no group name supplied")
+	case 1:
+		return errors.New("no username supplied")
+	}
+	c.Group = args[0]
+	for _, user := range strings.Split(args[1], ",") {
+		if user == "" {
+			return errors.Errorf("invalid user list %q", args[1])
+		}
+		c.Users = append(c.Users, user)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *groupMembersBase) getAPI() (GroupMembersAPI, func(), error) {
+	if c.api != nil {
+		return c.api, func() {}, nil
+	}
+	api, err := c.NewUserManagerAPIClient()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return api, func() { api.Close() }, nil
+}
+
+// NewAddUserToGroupCommand returns a command to add users to a group.
+func NewAddUserToGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addUserToGroupCommand{})
+}
+
+// addUserToGroupCommand adds users to a group.
+type addUserToGroupCommand struct {
+	groupMembersBase
+}
+
+// Info implements Command.Info.
+func (c *addUserToGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-user-to-group",
+		Args:    "<group name> <user name>[,<user name>...]",
+		Purpose: usageAddUserToGroupSummary,
+		Doc:     usageAddUserToGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *addUserToGroupCommand) Run(ctx *cmd.Context) error {
+	api, closer, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	if err := api.AddGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Added %s to group %q", strings.Join(c.Users, ", "), c.Group)
+	return nil
+}
+
+// NewRemoveUserFromGroupCommand returns a command to remove users from
+// a group.
+func NewRemoveUserFromGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeUserFromGroupCommand{})
+}
+
+// removeUserFromGroupCommand removes users from a group.
+type removeUserFromGroupCommand struct {
+	groupMembersBase
+}
+
+// Info implements Command.Info.
+func (c *removeUserFromGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-user-from-group",
+		Args:    "<group name> <user name>[,<user name>...]",
+		Purpose: usageRemoveUserFromGroupSummary,
+		Doc:     usageRemoveUserFromGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeUserFromGroupCommand) Run(ctx *cmd.Context) error {
+	api, closer, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	if err := api.RemoveGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Removed %s from group %q", strings.Join(c.Users, ", "), c.Group)
+	return nil
+}
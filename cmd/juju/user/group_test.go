@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type GroupSuite struct {
+	BaseSuite
+	mock *mockGroupAPI
+}
+
+var _ = gc.Suite(&GroupSuite{})
+
+func (s *GroupSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockGroupAPI{Stub: &jujutesting.Stub{}}
+}
+
+func (s *GroupSuite) TestAddGroupInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no group name supplied",
+	}, {
+		args:     []string{"devs", "ops"},
+		errMatch: `unrecognized args: \["ops"\]`,
+	}, {
+		args: []string{"devs"},
+	}} {
+		c.Logf("test %d, args %v", i, test.args)
+		err := testing.InitCommand(user.NewAddGroupCommandForTest(nil, s.store), test.args)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *GroupSuite) TestAddGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store), "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mock.CheckCalls(c, []jujutesting.StubCall{{"AddGroup", []interface{}{"devs"}}})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Group \"devs\" added\n")
+}
+
+func (s *GroupSuite) TestGroupMembersInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no group name supplied",
+	}, {
+		args:     []string{"devs"},
+		errMatch: "no username supplied",
+	}, {
+		args:     []string{"devs", "bob,"},
+		errMatch: `invalid user list "bob,"`,
+	}, {
+		args:     []string{"devs", "bob", "mary"},
+		errMatch: `unrecognized args: \["mary"\]`,
+	}, {
+		args: []string{"devs", "bob,mary"},
+	}} {
+		c.Logf("test %d, args %v", i, test.args)
+		err := testing.InitCommand(user.NewAddUserToGroupCommandForTest(nil, s.store), test.args)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *GroupSuite) TestAddUserToGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewAddUserToGroupCommandForTest(s.mock, s.store), "devs", "bob,mary")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mock.CheckCalls(c, []jujutesting.StubCall{{"AddGroupMembers", []interface{}{"devs", []string{"bob", "mary"}}}})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Added bob, mary to group \"devs\"\n")
+}
+
+func (s *GroupSuite) TestRemoveUserFromGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewRemoveUserFromGroupCommandForTest(s.mock, s.store), "devs", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mock.CheckCalls(c, []jujutesting.StubCall{{"RemoveGroupMembers", []interface{}{"devs", []string{"bob"}}}})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Removed bob from group \"devs\"\n")
+}
+
+func (s *GroupSuite) TestAddUserToGroupError(c *gc.C) {
+	s.mock.SetErrors(errors.New(`group "devs" not found`))
+	_, err := testing.RunCommand(c, user.NewAddUserToGroupCommandForTest(s.mock, s.store), "devs", "bob")
+	c.Assert(err, gc.ErrorMatches, `group "devs" not found`)
+}
+
+type mockGroupAPI struct {
+	*jujutesting.Stub
+}
+
+func (m *mockGroupAPI) AddGroup(name string) error {
+	m.MethodCall(m, "AddGroup", name)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) AddGroupMembers(group string, usernames ...string) error {
+	m.MethodCall(m, "AddGroupMembers", group, usernames)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) RemoveGroupMembers(group string, usernames ...string) error {
+	m.MethodCall(m, "RemoveGroupMembers", group, usernames)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) Close() error {
+	return nil
+}
@@ -27,12 +27,13 @@ func DefaultIntrospectionSocketName(entityTag names.Tag) string {
 // introspectionConfig defines the various components that the introspection
 // worker reports on or needs to start up.
 type introspectionConfig struct {
-	Agent              agent.Agent
-	Engine             *dependency.Engine
-	StatePoolReporter  introspection.IntrospectionReporter
-	PrometheusGatherer prometheus.Gatherer
-	NewSocketName      func(names.Tag) string
-	WorkerFunc         func(config introspection.Config) (worker.Worker, error)
+	Agent               agent.Agent
+	Engine              *dependency.Engine
+	StatePoolReporter   introspection.IntrospectionReporter
+	APIRequestsReporter introspection.IntrospectionReporter
+	PrometheusGatherer  prometheus.Gatherer
+	NewSocketName       func(names.Tag) string
+	WorkerFunc          func(config introspection.Config) (worker.Worker, error)
 }
 
 // startIntrospection creates the introspection worker. It cannot and should
@@ -52,6 +53,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		SocketName:         socketName,
 		DepEngine:          cfg.Engine,
 		StatePool:          cfg.StatePoolReporter,
+		APIRequests:        cfg.APIRequestsReporter,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 	})
 	if err != nil {
@@ -89,3 +91,12 @@ func (h *statePoolHolder) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// IntrospectionReport is used by the introspection worker to report
+// the API requests in flight.
+func (h *apiRequestsHolder) IntrospectionReport() string {
+	if h.tracker == nil {
+		return "agent is not running an API server"
+	}
+	return h.tracker.IntrospectionReport()
+}
@@ -50,6 +50,7 @@ import (
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/apiserver/observer/requesttracker"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
@@ -79,6 +80,7 @@ import (
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/accessreaper"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
@@ -311,6 +313,7 @@ func NewMachineAgent(
 		txnmetricsCollector:         txnmetrics.New(),
 		preUpgradeSteps:             preUpgradeSteps,
 		statePool:                   &statePoolHolder{},
+		apiRequests:                 &apiRequestsHolder{},
 	}
 	if err := a.prometheusRegistry.Register(
 		logsendermetrics.BufferedLogWriterMetrics{bufferedLogger},
@@ -367,12 +370,21 @@ type MachineAgent struct {
 	// worker can have a single thing to hold that can report on the state pool.
 	// The content of the state pool holder is updated as the pool changes.
 	statePool *statePoolHolder
+
+	// The apiRequests holder holds a reference to the tracker of
+	// requests in flight in the current API server, so that the
+	// introspection worker can report on them.
+	apiRequests *apiRequestsHolder
 }
 
 type statePoolHolder struct {
 	pool *state.StatePool
 }
 
+type apiRequestsHolder struct {
+	tracker *requesttracker.Tracker
+}
+
 // IsRestorePreparing returns bool representing if we are in restore mode
 // but not running restore.
 func (a *MachineAgent) IsRestorePreparing() bool {
@@ -553,12 +565,13 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			return nil, err
 		}
 		if err := startIntrospection(introspectionConfig{
-			Agent:              a,
-			Engine:             engine,
-			StatePoolReporter:  a.statePool,
-			NewSocketName:      a.newIntrospectionSocketName,
-			PrometheusGatherer: a.prometheusRegistry,
-			WorkerFunc:         introspection.NewWorker,
+			Agent:               a,
+			Engine:              engine,
+			StatePoolReporter:   a.statePool,
+			APIRequestsReporter: a.apiRequests,
+			NewSocketName:       a.newIntrospectionSocketName,
+			PrometheusGatherer:  a.prometheusRegistry,
+			WorkerFunc:          introspection.NewWorker,
 		}); err != nil {
 			// If the introspection worker failed to start, we just log error
 			// but continue. It is very unlikely to happen in the real world
@@ -1083,6 +1096,10 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "accessreaper", func() (worker.Worker, error) {
+				return accessreaper.New(st, time.Minute, clock.WallClock), nil
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1198,6 +1215,16 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	requestTracker, err := requesttracker.NewTracker(requesttracker.Config{
+		Clock:                clock.WallClock,
+		Logger:               loggo.GetLogger("juju.apiserver.slowrequests"),
+		SlowRequestThreshold: controllerConfig.APISlowRequestThreshold(),
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create API request tracker")
+	}
+	a.apiRequests.tracker = requestTracker
+
 	newObserver, err := newObserverFn(
 		controllerConfig,
 		clock.WallClock,
@@ -1206,6 +1233,7 @@ func (a *MachineAgent) newAPIserverWorker(
 		newAuditEntrySink(st, logDir),
 		auditErrorHandler,
 		a.prometheusRegistry,
+		requestTracker,
 	)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
@@ -1218,6 +1246,7 @@ func (a *MachineAgent) newAPIserverWorker(
 			introspection.ReportSources{
 				DependencyEngine:   dependencyReporter,
 				StatePool:          statePool,
+				APIRequests:        requestTracker,
 				PrometheusGatherer: a.prometheusRegistry,
 			}, f)
 	}
@@ -1235,6 +1264,11 @@ func (a *MachineAgent) newAPIserverWorker(
 		AutocertURL:                   controllerConfig.AutocertURL(),
 		AutocertDNSName:               controllerConfig.AutocertDNSName(),
 		AllowModelAccess:              controllerConfig.AllowModelAccess(),
+		AgentLoginRateLimit:           controllerConfig.AgentLoginRateLimit(),
+		MaxAgentConnections:           controllerConfig.MaxAgentConnections(),
+		MaxUserConnections:            controllerConfig.MaxUserConnections(),
+		UserLoginRateLimit:            controllerConfig.UserLoginRateLimit(),
+		PrometheusRegisterer:          a.prometheusRegistry,
 		NewObserver:                   newObserver,
 		StatePool:                     statePool,
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
@@ -1278,6 +1312,7 @@ func newObserverFn(
 	persistAuditEntry audit.AuditEntrySinkFn,
 	auditErrorHandler observer.ErrorHandler,
 	prometheusRegisterer prometheus.Registerer,
+	requestTracker *requesttracker.Tracker,
 ) (observer.ObserverFactory, error) {
 
 	var observerFactories []observer.ObserverFactory
@@ -1314,6 +1349,9 @@ func newObserverFn(
 	}
 	observerFactories = append(observerFactories, metricObserver)
 
+	// Tracking of requests in flight, and logging of slow requests.
+	observerFactories = append(observerFactories, requestTracker.NewObserver)
+
 	return observer.ObserverFactoryMultiplexer(observerFactories...), nil
 
 }
@@ -334,6 +334,7 @@ func (s *MachineSuite) TestManageModel(c *gc.C) {
 	c.Logf("started test agent, waiting for workers...")
 	r0 := s.singularRecord.nextRunner(c)
 	r0.waitForWorker(c, "txnpruner")
+	r0.waitForWorker(c, "accessreaper")
 
 	// Check that the provisioner and firewaller are alive by doing
 	// a rudimentary check that it responds to state changes.
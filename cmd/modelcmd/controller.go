@@ -4,6 +4,9 @@
 package modelcmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -184,27 +187,69 @@ func (c *ControllerCommandBase) newAPIRoot(modelName string) (api.Connection, er
 	return opener.Open(c.store, c.controllerName, modelName)
 }
 
-// ModelUUIDs returns the model UUIDs for the given model names.
+// ModelUUIDs returns the model UUIDs for the given model names. Models
+// that are not cached locally are looked up with a single refresh from
+// the controller, and every model that cannot be found is reported in
+// the returned error.
 func (c *ControllerCommandBase) ModelUUIDs(modelNames []string) ([]string, error) {
-	var result []string
 	store := c.ClientStore()
 	controllerName := c.ControllerName()
-	for _, modelName := range modelNames {
+	result := make([]string, len(modelNames))
+	var missing []int
+	for i, modelName := range modelNames {
 		model, err := store.ModelByName(controllerName, modelName)
 		if errors.IsNotFound(err) {
-			// The model isn't known locally, so query the models available in the controller.
-			logger.Infof("model %q not cached locally, refreshing models from controller", modelName)
-			if err := c.RefreshModels(store, controllerName); err != nil {
-				return nil, errors.Annotatef(err, "refreshing model %q", modelName)
-			}
-			model, err = store.ModelByName(controllerName, modelName)
+			missing = append(missing, i)
+			continue
 		}
 		if err != nil {
-			return nil, errors.Annotatef(err, "model %q not found", modelName)
+			return nil, errors.Annotatef(err, "model %q", modelName)
 		}
-		result = append(result, model.ModelUUID)
+		result[i] = model.ModelUUID
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	// Some models aren't known locally, so query the models
+	// available in the controller.
+	missingNames := make([]string, len(missing))
+	for i, index := range missing {
+		missingNames[i] = modelNames[index]
+	}
+	logger.Infof("models %s not cached locally, refreshing models from controller", quoteNames(missingNames))
+	if err := c.RefreshModels(store, controllerName); err != nil {
+		return nil, errors.Annotatef(err, "refreshing models %s", quoteNames(missingNames))
+	}
+	var notFound []string
+	for _, index := range missing {
+		modelName := modelNames[index]
+		model, err := store.ModelByName(controllerName, modelName)
+		if errors.IsNotFound(err) {
+			notFound = append(notFound, modelName)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "model %q", modelName)
+		}
+		result[index] = model.ModelUUID
+	}
+	switch len(notFound) {
+	case 0:
+		return result, nil
+	case 1:
+		return nil, errors.NotFoundf("model %q", notFound[0])
+	}
+	return nil, errors.NotFoundf("models %s", quoteNames(notFound))
+}
+
+// quoteNames returns the names quoted and separated by commas.
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
 	}
-	return result, nil
+	return strings.Join(quoted, ", ")
 }
 
 // WrapControllerOption specifies an option to the WrapController function.
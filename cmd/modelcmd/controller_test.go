@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -57,6 +58,76 @@ func (s *ControllerCommandSuite) TestWrapWithoutFlags(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "flag provided but not defined: -s")
 }
 
+func (s *ControllerCommandSuite) newModelUUIDsCommand(c *gc.C, api *fakeModelAPI) *testControllerCommand {
+	store := jujuclienttesting.NewMemStore()
+	store.CurrentControllerName = "foo"
+	store.Accounts["foo"] = jujuclient.AccountDetails{
+		User: "bar",
+	}
+	store.Controllers["foo"] = jujuclient.ControllerDetails{}
+	store.Models["foo"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"bar/cached": {"cached-uuid"},
+		},
+	}
+	_, cmd, err := initTestControllerCommand(c, store)
+	c.Assert(err, jc.ErrorIsNil)
+	cmd.SetModelAPI(api)
+	return cmd
+}
+
+func (s *ControllerCommandSuite) TestModelUUIDsRefreshesOnce(c *gc.C) {
+	api := &fakeModelAPI{
+		Stub: &testing.Stub{},
+		models: []base.UserModel{
+			{Name: "one", UUID: "one-uuid", Owner: "bar"},
+			{Name: "two", UUID: "two-uuid", Owner: "bar"},
+		},
+	}
+	cmd := s.newModelUUIDsCommand(c, api)
+	uuids, err := cmd.ModelUUIDs([]string{"one", "cached", "two"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, jc.DeepEquals, []string{"one-uuid", "cached-uuid", "two-uuid"})
+	api.CheckCallNames(c, "ListModels", "Close")
+}
+
+func (s *ControllerCommandSuite) TestModelUUIDsReportsAllMissing(c *gc.C) {
+	api := &fakeModelAPI{
+		Stub: &testing.Stub{},
+		models: []base.UserModel{
+			{Name: "one", UUID: "one-uuid", Owner: "bar"},
+		},
+	}
+	cmd := s.newModelUUIDsCommand(c, api)
+	_, err := cmd.ModelUUIDs([]string{"one", "missing", "cached", "gone"})
+	c.Assert(err, gc.ErrorMatches, `models "missing", "gone" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	api.CheckCallNames(c, "ListModels", "Close")
+}
+
+func (s *ControllerCommandSuite) TestModelUUIDsRefreshError(c *gc.C) {
+	api := &fakeModelAPI{Stub: &testing.Stub{}}
+	api.SetErrors(errors.New("boom"))
+	cmd := s.newModelUUIDsCommand(c, api)
+	_, err := cmd.ModelUUIDs([]string{"missing", "gone"})
+	c.Assert(err, gc.ErrorMatches, `refreshing models "missing", "gone": boom`)
+}
+
+type fakeModelAPI struct {
+	*testing.Stub
+	models []base.UserModel
+}
+
+func (f *fakeModelAPI) ListModels(user string) ([]base.UserModel, error) {
+	f.MethodCall(f, "ListModels", user)
+	return f.models, f.NextErr()
+}
+
+func (f *fakeModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
 type testControllerCommand struct {
 	modelcmd.ControllerCommandBase
 }
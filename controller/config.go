@@ -5,6 +5,7 @@ package controller
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -73,6 +74,38 @@ const (
 	// detault
 	MongoMemoryProfile = "mongo-memory-profile"
 
+	// PasswordMinLength sets the minimum length of user passwords.
+	PasswordMinLength = "password-min-length"
+
+	// PasswordMinCharacterClasses sets how many character classes
+	// (lower case letters, upper case letters, digits and other
+	// characters) a user password must contain.
+	PasswordMinCharacterClasses = "password-min-character-classes"
+
+	// AgentLoginRateLimit sets how many agent logins the API server
+	// will process concurrently. Agents beyond this are asked to try
+	// again later.
+	AgentLoginRateLimit = "agent-login-rate-limit"
+
+	// MaxAgentConnections sets the maximum number of concurrent agent
+	// connections to each API server. Zero means no limit.
+	MaxAgentConnections = "max-agent-connections"
+
+	// MaxUserConnections sets the maximum number of concurrent
+	// connections any one user may hold to each API server.
+	// Controller superusers are not limited. Zero means no limit.
+	MaxUserConnections = "max-user-connections"
+
+	// UserLoginRateLimit sets the maximum number of logins per minute
+	// any one user may make to each API server. Controller superusers
+	// are not limited. Zero means no limit.
+	UserLoginRateLimit = "user-login-rate-limit"
+
+	// APISlowRequestThreshold sets how long an API request may take
+	// before it is logged as slow. A zero duration disables the slow
+	// request log.
+	APISlowRequestThreshold = "api-slow-request-threshold"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -91,6 +124,14 @@ const (
 
 	// DefaultMongoMemoryProfile is the default profile used by mongo.
 	DefaultMongoMemoryProfile = MongoProfLow
+
+	// DefaultAgentLoginRateLimit is the default number of agent logins
+	// processed concurrently by the API server.
+	DefaultAgentLoginRateLimit = 10
+
+	// DefaultAPISlowRequestThreshold is the default duration after
+	// which an API request is logged as slow.
+	DefaultAPISlowRequestThreshold = 10 * time.Second
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -107,6 +148,12 @@ var ControllerOnlyConfigAttributes = []string{
 	SetNUMAControlPolicyKey,
 	StatePort,
 	MongoMemoryProfile,
+	PasswordMinLength,
+	PasswordMinCharacterClasses,
+	AgentLoginRateLimit,
+	MaxAgentConnections,
+	MaxUserConnections,
+	APISlowRequestThreshold,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -269,6 +316,63 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// PasswordMinLength returns the minimum length of user passwords, or
+// zero if there is none.
+func (c Config) PasswordMinLength() int {
+	value, _ := c[PasswordMinLength].(int)
+	return value
+}
+
+// PasswordMinCharacterClasses returns how many character classes a
+// user password must contain, or zero if there is no requirement.
+func (c Config) PasswordMinCharacterClasses() int {
+	value, _ := c[PasswordMinCharacterClasses].(int)
+	return value
+}
+
+// AgentLoginRateLimit returns how many agent logins the API server
+// will process concurrently.
+func (c Config) AgentLoginRateLimit() int {
+	if value, ok := c[AgentLoginRateLimit].(int); ok {
+		return value
+	}
+	return DefaultAgentLoginRateLimit
+}
+
+// MaxAgentConnections returns the maximum number of concurrent agent
+// connections to an API server, or zero if there is no limit.
+func (c Config) MaxAgentConnections() int {
+	value, _ := c[MaxAgentConnections].(int)
+	return value
+}
+
+// MaxUserConnections returns the maximum number of concurrent
+// connections a user may hold to an API server, or zero if there
+// is no limit.
+func (c Config) MaxUserConnections() int {
+	value, _ := c[MaxUserConnections].(int)
+	return value
+}
+
+// APISlowRequestThreshold returns how long an API request may take
+// before it is logged as slow, or zero if slow requests are not logged.
+func (c Config) APISlowRequestThreshold() time.Duration {
+	value, ok := c[APISlowRequestThreshold].(string)
+	if !ok {
+		return DefaultAPISlowRequestThreshold
+	}
+	// The value has been validated, so we don't expect this to fail.
+	threshold, _ := time.ParseDuration(value)
+	return threshold
+}
+
+// UserLoginRateLimit returns the maximum number of logins per minute a
+// user may make to an API server, or zero if there is no limit.
+func (c Config) UserLoginRateLimit() int {
+	value, _ := c[UserLoginRateLimit].(int)
+	return value
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -310,6 +414,30 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[PasswordMinLength].(int); ok && v < 0 {
+		return errors.Errorf("%s: expected a non-negative number, got %d", PasswordMinLength, v)
+	}
+	if v, ok := c[PasswordMinCharacterClasses].(int); ok && (v < 0 || v > 4) {
+		return errors.Errorf("%s: expected a number between 0 and 4, got %d", PasswordMinCharacterClasses, v)
+	}
+	if v, ok := c[AgentLoginRateLimit].(int); ok && v < 1 {
+		return errors.Errorf("%s: expected a positive number, got %d", AgentLoginRateLimit, v)
+	}
+	for _, key := range []string{MaxAgentConnections, MaxUserConnections, UserLoginRateLimit} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("%s: expected a non-negative number, got %d", key, v)
+		}
+	}
+	if v, ok := c[APISlowRequestThreshold].(string); ok {
+		threshold, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, APISlowRequestThreshold)
+		}
+		if threshold < 0 {
+			return errors.Errorf("%s: expected a non-negative duration, got %v", APISlowRequestThreshold, v)
+		}
+	}
+
 	return nil
 }
 
@@ -320,25 +448,39 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
+	AuditingEnabled:             schema.Bool(),
+	APIPort:                     schema.ForceInt(),
+	StatePort:                   schema.ForceInt(),
+	IdentityURL:                 schema.String(),
+	IdentityPublicKey:           schema.String(),
+	SetNUMAControlPolicyKey:     schema.Bool(),
+	AutocertURLKey:              schema.String(),
+	AutocertDNSNameKey:          schema.String(),
+	AllowModelAccessKey:         schema.Bool(),
+	MongoMemoryProfile:          schema.String(),
+	PasswordMinLength:           schema.ForceInt(),
+	PasswordMinCharacterClasses: schema.ForceInt(),
+	AgentLoginRateLimit:         schema.ForceInt(),
+	MaxAgentConnections:         schema.ForceInt(),
+	MaxUserConnections:          schema.ForceInt(),
+	UserLoginRateLimit:          schema.ForceInt(),
+	APISlowRequestThreshold:     schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
+	APIPort:                     DefaultAPIPort,
+	AuditingEnabled:             DefaultAuditingEnabled,
+	StatePort:                   DefaultStatePort,
+	IdentityURL:                 schema.Omit,
+	IdentityPublicKey:           schema.Omit,
+	SetNUMAControlPolicyKey:     DefaultNUMAControlPolicy,
+	AutocertURLKey:              schema.Omit,
+	AutocertDNSNameKey:          schema.Omit,
+	AllowModelAccessKey:         schema.Omit,
+	MongoMemoryProfile:          schema.Omit,
+	PasswordMinLength:           schema.Omit,
+	PasswordMinCharacterClasses: schema.Omit,
+	AgentLoginRateLimit:         schema.Omit,
+	MaxAgentConnections:         schema.Omit,
+	MaxUserConnections:          schema.Omit,
+	UserLoginRateLimit:          schema.Omit,
+	APISlowRequestThreshold:     schema.Omit,
 })
@@ -121,8 +121,97 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "negative password minimum length",
+	config: controller.Config{
+		controller.PasswordMinLength: -1,
+		controller.CACertKey:         testing.CACert,
+	},
+	expectError: `password-min-length: expected a non-negative number, got -1`,
+}, {
+	about: "too many password character classes",
+	config: controller.Config{
+		controller.PasswordMinCharacterClasses: 5,
+		controller.CACertKey:                   testing.CACert,
+	},
+	expectError: `password-min-character-classes: expected a number between 0 and 4, got 5`,
+}, {
+	about: "zero agent login rate limit",
+	config: controller.Config{
+		controller.AgentLoginRateLimit: 0,
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `agent-login-rate-limit: expected a positive number, got 0`,
+}, {
+	about: "negative max agent connections",
+	config: controller.Config{
+		controller.MaxAgentConnections: -1,
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `max-agent-connections: expected a non-negative number, got -1`,
+}, {
+	about: "negative max user connections",
+	config: controller.Config{
+		controller.MaxUserConnections: -1,
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `max-user-connections: expected a non-negative number, got -1`,
+}, {
+	about: "negative user login rate limit",
+	config: controller.Config{
+		controller.UserLoginRateLimit: -1,
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `user-login-rate-limit: expected a non-negative number, got -1`,
+}, {
+	about: "invalid slow request threshold",
+	config: controller.Config{
+		controller.APISlowRequestThreshold: "soon",
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `api-slow-request-threshold: time: invalid duration "?soon"?`,
+}, {
+	about: "negative slow request threshold",
+	config: controller.Config{
+		controller.APISlowRequestThreshold: "-1s",
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `api-slow-request-threshold: expected a non-negative duration, got -1s`,
 }}
 
+func (s *ConfigSuite) TestConnectionLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentLoginRateLimit(), gc.Equals, controller.DefaultAgentLoginRateLimit)
+	c.Assert(cfg.MaxAgentConnections(), gc.Equals, 0)
+	c.Assert(cfg.MaxUserConnections(), gc.Equals, 0)
+	c.Assert(cfg.UserLoginRateLimit(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AgentLoginRateLimit: 20,
+		controller.MaxAgentConnections: "500",
+		controller.MaxUserConnections:  5,
+		controller.UserLoginRateLimit:  30,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentLoginRateLimit(), gc.Equals, 20)
+	c.Assert(cfg.MaxAgentConnections(), gc.Equals, 500)
+	c.Assert(cfg.MaxUserConnections(), gc.Equals, 5)
+	c.Assert(cfg.UserLoginRateLimit(), gc.Equals, 30)
+}
+
+func (s *ConfigSuite) TestAPISlowRequestThreshold(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APISlowRequestThreshold(), gc.Equals, controller.DefaultAPISlowRequestThreshold)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.APISlowRequestThreshold: "2m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APISlowRequestThreshold(), gc.Equals, 2*time.Minute)
+}
+
 func (s *ConfigSuite) TestValidate(c *gc.C) {
 	for i, test := range validateTests {
 		c.Logf("test %d: %v", i, test.about)
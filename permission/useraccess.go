@@ -32,6 +32,8 @@ type UserAccess struct {
 	DisplayName string
 	// UserName is the actual username for this access.
 	UserName string
+	// Expires, if set, is when the access lapses.
+	Expires *time.Time
 }
 
 // IsEmptyUserAccess returns true if the passed UserAccess instance
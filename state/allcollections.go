@@ -146,6 +146,15 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds groups of users that can be granted
+		// access as a whole.
+		groupsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"members"},
+			}},
+		},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -380,6 +389,14 @@ func allCollections() collectionSchema {
 		// unit relation settings, model config, etc etc etc.
 		settingsC: {},
 
+		// This collection holds the history of changes made to
+		// application config settings, one document per revision.
+		configHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "revision"},
+			}},
+		},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		statusesC:           {},
@@ -411,6 +428,9 @@ func allCollections() collectionSchema {
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"origin-name"},
+			}},
 		},
 	}
 	if featureflag.Enabled(feature.CrossModelRelations) {
@@ -457,6 +477,7 @@ const (
 	cloudimagemetadataC      = "cloudimagemetadata"
 	cloudsC                  = "clouds"
 	cloudCredentialsC        = "cloudCredentials"
+	configHistoryC           = "confighistory"
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
@@ -464,6 +485,7 @@ const (
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	globalSettingsC          = "globalSettings"
+	groupsC                  = "groups"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
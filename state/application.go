@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -42,6 +43,7 @@ type applicationDoc struct {
 	Series               string     `bson:"series"`
 	Subordinate          bool       `bson:"subordinate"`
 	CharmURL             *charm.URL `bson:"charmurl"`
+	PreviousCharmURL     *charm.URL `bson:"previous-charmurl,omitempty"`
 	Channel              string     `bson:"cs-channel"`
 	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
 	ForceCharm           bool       `bson:"forcecharm"`
@@ -52,6 +54,11 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// ExposedEndpoints holds the port ranges that are exposed for
+	// individual endpoints of an exposed application. When empty,
+	// all opened ports of an exposed application are reachable.
+	ExposedEndpoints map[string][]network.PortRange `bson:"exposed-endpoints,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -300,7 +307,13 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeLeadershipSettingsOp(name),
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
+		newCleanupOp(cleanupApplicationConfigHistory, name),
 	)
+	accessOps, err := removeApplicationAccessOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, accessOps...)
 	return ops, nil
 }
 
@@ -311,8 +324,9 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
-// See ClearExposed and IsExposed.
+// SetExposed marks the application as exposed, with all of its opened
+// ports reachable; any exposed endpoints are forgotten.
+// See ClearExposed, ExposeEndpoints and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
@@ -328,12 +342,81 @@ func (a *Application) setExposed(exposed bool) (err error) {
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		},
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = nil
+	return nil
+}
+
+// ExposedEndpoints returns the port ranges exposed for individual
+// endpoints of the application, keyed by endpoint name. An exposed
+// application with no exposed endpoints has all of its opened ports
+// reachable. See ExposeEndpoints.
+func (a *Application) ExposedEndpoints() map[string][]network.PortRange {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string][]network.PortRange, len(a.doc.ExposedEndpoints))
+	for name, portRanges := range a.doc.ExposedEndpoints {
+		result[name] = append([]network.PortRange(nil), portRanges...)
+	}
+	return result
+}
+
+// ExposeEndpoints marks the application as exposed, restricting
+// access from outside the local deployment network to the given port
+// ranges of the named endpoints. The supplied map replaces any
+// previously exposed endpoints. See ClearExposed and ExposedEndpoints.
+func (a *Application) ExposeEndpoints(endpoints map[string][]network.PortRange) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot expose endpoints of application %q", a)
+	if len(endpoints) == 0 {
+		return errors.New("no endpoints specified")
+	}
+	exposed := make(map[string][]network.PortRange, len(endpoints))
+	for name, portRanges := range endpoints {
+		if _, err := a.Endpoint(name); err != nil {
+			return errors.Trace(err)
+		}
+		if len(portRanges) == 0 {
+			return errors.Errorf("no port ranges specified for endpoint %q", name)
+		}
+		for _, portRange := range portRanges {
+			if err := portRange.Validate(); err != nil {
+				return errors.Annotatef(err, "endpoint %q", name)
+			}
+		}
+		exposed[name] = append([]network.PortRange(nil), portRanges...)
+	}
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		// The endpoints were validated against the current charm.
+		Assert: append(isAliveDoc, bson.DocElem{"charmurl", a.doc.CharmURL}),
+		Update: bson.D{{"$set", bson.D{
+			{"exposed", true},
+			{"exposed-endpoints", exposed},
+		}}},
+	}}
+	if err := a.st.runTransaction(ops); err == txn.ErrAborted {
+		if err := a.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		if a.doc.Life != Alive {
+			return errNotAlive
+		}
+		return errors.New("charm changed while exposing endpoints")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Exposed = true
+	a.doc.ExposedEndpoints = exposed
 	return nil
 }
 
@@ -361,6 +444,14 @@ func (a *Application) CharmModifiedVersion() int {
 	return a.doc.CharmModifiedVersion
 }
 
+// PreviousCharmURL returns the URL of the charm the application used
+// before its most recent charm upgrade, or nil if the charm has never
+// been changed. It can be used to roll back an upgrade with SetCharm,
+// provided the charm has not since been removed from state.
+func (a *Application) PreviousCharmURL() *charm.URL {
+	return a.doc.PreviousCharmURL
+}
+
 // CharmURL returns the application's charm URL, and whether units should upgrade
 // to the charm with that URL even if they are in an error state.
 func (a *Application) CharmURL() (curl *charm.URL, force bool) {
@@ -557,6 +648,7 @@ func (a *Application) changeCharmOps(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
+	author string,
 ) ([]txn.Op, error) {
 	// Build the new application config from what can be used of the old one.
 	var newSettings charm.Settings
@@ -699,12 +791,14 @@ func (a *Application) changeCharmOps(
 		settingsOp,
 		// Create storage constraints.
 		storageConstraintsOp,
-		// Update the charm URL and force flag (if relevant).
+		// Update the charm URL and force flag (if relevant), remembering
+		// the charm we're upgrading from.
 		{
 			C:  applicationsC,
 			Id: a.doc.DocID,
 			Update: bson.D{{"$set", bson.D{
 				{"charmurl", ch.URL()},
+				{"previous-charmurl", a.doc.CharmURL},
 				{"cs-channel", channel},
 				{"forcecharm", forceUnits},
 			}}},
@@ -713,6 +807,14 @@ func (a *Application) changeCharmOps(
 	ops = append(ops, checkStorageOps...)
 	ops = append(ops, upgradeStorageOps...)
 
+	// Record the settings carried over to the new charm, so that the
+	// config history reflects the settings actually in use.
+	historyOp, err := a.addConfigRevisionOp(ch.URL(), author, newSettings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, historyOp)
+
 	ops = append(ops, incCharmModifiedVersionOps(a.doc.DocID)...)
 
 	// Add any extra peer relations that need creation.
@@ -855,6 +957,10 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// Author identifies who requested the upgrade, if known. It is
+	// recorded against the resulting config revision.
+	Author string
 }
 
 // SetCharm changes the charm for the application.
@@ -914,7 +1020,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		}
 	}
 
-	updatedSettings, err := cfg.Charm.Config().ValidateSettings(cfg.ConfigSettings)
+	updatedSettings, err := validateConfigSettings(cfg.Charm.Config(), cfg.ConfigSettings)
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
@@ -971,6 +1077,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				cfg.ForceUnits,
 				cfg.ResourceIDs,
 				cfg.StorageConstraints,
+				cfg.Author,
 			)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -984,6 +1091,9 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err := a.st.run(buildTxn); err != nil {
 		return err
 	}
+	if acopy.doc.CharmURL.String() != cfg.Charm.URL().String() {
+		a.doc.PreviousCharmURL = acopy.doc.CharmURL
+	}
 	a.doc.CharmURL = cfg.Charm.URL()
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
@@ -1030,37 +1140,47 @@ func (a *Application) newUnitName() (string, error) {
 // to include additional assertions for the application document.  This method
 // assumes that the application already exists in the db.
 func (a *Application) addUnitOps(principalName string, asserts bson.D) (string, []txn.Op, error) {
+	args, err := a.unitOpsArgs(principalName)
+	if err != nil {
+		return "", nil, err
+	}
+	names, ops, err := a.addUnitOpsWithCons(args)
+	if err != nil {
+		return names, ops, err
+	}
+	// we verify the application is alive
+	asserts = append(isAliveDoc, asserts...)
+	ops = append(ops, a.incUnitCountOp(asserts))
+	return names, ops, err
+}
+
+// unitOpsArgs returns the arguments needed by addUnitOpsWithCons to
+// create a unit of the application, resolving the application's
+// constraints and storage constraints as they are currently stored.
+func (a *Application) unitOpsArgs(principalName string) (applicationAddUnitOpsArgs, error) {
 	var cons constraints.Value
 	if !a.doc.Subordinate {
 		scons, err := a.Constraints()
 		if errors.IsNotFound(err) {
-			return "", nil, errors.NotFoundf("application %q", a.Name())
+			return applicationAddUnitOpsArgs{}, errors.NotFoundf("application %q", a.Name())
 		}
 		if err != nil {
-			return "", nil, err
+			return applicationAddUnitOpsArgs{}, err
 		}
 		cons, err = a.st.resolveConstraints(scons)
 		if err != nil {
-			return "", nil, err
+			return applicationAddUnitOpsArgs{}, err
 		}
 	}
 	storageCons, err := a.StorageConstraints()
 	if err != nil {
-		return "", nil, err
+		return applicationAddUnitOpsArgs{}, err
 	}
-	args := applicationAddUnitOpsArgs{
+	return applicationAddUnitOpsArgs{
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
-	}
-	names, ops, err := a.addUnitOpsWithCons(args)
-	if err != nil {
-		return names, ops, err
-	}
-	// we verify the application is alive
-	asserts = append(isAliveDoc, asserts...)
-	ops = append(ops, a.incUnitCountOp(asserts))
-	return names, ops, err
+	}, nil
 }
 
 type applicationAddUnitOpsArgs struct {
@@ -1187,10 +1307,16 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 
 // incUnitCountOp returns the operation to increment the application's unit count.
 func (a *Application) incUnitCountOp(asserts bson.D) txn.Op {
+	return a.incUnitCountByOp(1, asserts)
+}
+
+// incUnitCountByOp returns the operation to increase the application's
+// unit count by n.
+func (a *Application) incUnitCountByOp(n int, asserts bson.D) txn.Op {
 	op := txn.Op{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
-		Update: bson.D{{"$inc", bson.D{{"unitcount", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"unitcount", n}}}},
 	}
 	if len(asserts) > 0 {
 		op.Assert = asserts
@@ -1219,6 +1345,49 @@ func (a *Application) AddUnit() (unit *Unit, err error) {
 	return a.st.Unit(name)
 }
 
+// AddUnits adds n new principal units to the application. All of the
+// units are created in a single transaction, so either all of them are
+// added or none are.
+func (a *Application) AddUnits(n int) (units []*Unit, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add units to application %q", a)
+	if n < 1 {
+		return nil, errors.NotValidf("unit count %d", n)
+	}
+	args, err := a.unitOpsArgs("")
+	if err != nil {
+		return nil, err
+	}
+	unitNames := make([]string, n)
+	var ops []txn.Op
+	for i := range unitNames {
+		name, unitOps, err := a.addUnitOpsWithCons(args)
+		if err != nil {
+			return nil, err
+		}
+		unitNames[i] = name
+		ops = append(ops, unitOps...)
+	}
+	ops = append(ops, a.incUnitCountByOp(n, isAliveDoc))
+
+	if err := a.st.runTransaction(ops); err == txn.ErrAborted {
+		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+			return nil, err
+		} else if !alive {
+			return nil, errors.New("application is not alive")
+		}
+		return nil, errors.New("inconsistent state")
+	} else if err != nil {
+		return nil, err
+	}
+	units = make([]*Unit, n)
+	for i, name := range unitNames {
+		if units[i], err = a.st.Unit(name); err != nil {
+			return nil, err
+		}
+	}
+	return units, nil
+}
+
 // removeUnitOps returns the operations necessary to remove the supplied unit,
 // assuming the supplied asserts apply to the unit document.
 func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
@@ -1339,6 +1508,24 @@ func (a *Application) Relations() (relations []*Relation, err error) {
 	return applicationRelations(a.st, a.doc.Name)
 }
 
+// PeerRelations returns the peer relations of the application. Peer
+// relations are created automatically for every peer endpoint declared
+// in the application's charm metadata.
+func (a *Application) PeerRelations() ([]*Relation, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, err
+	}
+	var peers []*Relation
+	for _, rel := range relations {
+		eps := rel.Endpoints()
+		if len(eps) == 1 && eps[0].Role == charm.RolePeer {
+			peers = append(peers, rel)
+		}
+	}
+	return peers, nil
+}
+
 func applicationRelations(st *State, name string) (relations []*Relation, err error) {
 	defer errors.DeferredAnnotatef(&err, "can't get relations for application %q", name)
 	relationsCollection, closer := st.getCollection(relationsC)
@@ -1368,11 +1555,22 @@ func (a *Application) ConfigSettings() (charm.Settings, error) {
 // UpdateConfigSettings changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
+	return a.updateConfigSettings("", changes)
+}
+
+// UpdateConfigSettingsBy changes the application's charm config settings
+// like UpdateConfigSettings, recording the given author against the
+// resulting config revision. See ConfigHistory.
+func (a *Application) UpdateConfigSettingsBy(author string, changes charm.Settings) error {
+	return a.updateConfigSettings(author, changes)
+}
+
+func (a *Application) updateConfigSettings(author string, changes charm.Settings) error {
 	charm, _, err := a.Charm()
 	if err != nil {
 		return err
 	}
-	changes, err = charm.Config().ValidateSettings(changes)
+	changes, err = validateConfigSettings(charm.Config(), changes)
 	if err != nil {
 		return err
 	}
@@ -1391,8 +1589,36 @@ func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
 			node.Set(name, value)
 		}
 	}
-	_, err = node.Write()
-	return err
+	_, ops := node.settingsUpdateOps()
+	if len(ops) == 0 {
+		return nil
+	}
+	historyOp, err := a.addConfigRevisionOp(a.doc.CharmURL, author, node.Map())
+	if err != nil {
+		return err
+	}
+	return node.write(append(ops, historyOp))
+}
+
+// validateConfigSettings validates the given settings against the charm
+// config schema, converting values to the option types declared in the
+// schema. Unlike charm.Config.ValidateSettings, which stops at the first
+// bad key, all offending keys are reported in an ErrInvalidConfigSettings.
+func validateConfigSettings(config *charm.Config, settings charm.Settings) (charm.Settings, error) {
+	validated := make(charm.Settings)
+	invalid := make(map[string]error)
+	for name, value := range settings {
+		result, err := config.ValidateSettings(charm.Settings{name: value})
+		if err != nil {
+			invalid[name] = err
+			continue
+		}
+		validated[name] = result[name]
+	}
+	if len(invalid) > 0 {
+		return nil, &ErrInvalidConfigSettings{Errors: invalid}
+	}
+	return validated, nil
 }
 
 // LeaderSettings returns a application's leader settings. If nothing has been set
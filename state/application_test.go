@@ -19,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -86,6 +87,27 @@ func (s *ApplicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsTrue)
 }
 
+func (s *ApplicationSuite) TestSetCharmRecordsPreviousCharmURL(c *gc.C) {
+	c.Assert(s.mysql.PreviousCharmURL(), gc.IsNil)
+
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.PreviousCharmURL(), gc.DeepEquals, s.charm.URL())
+
+	// Setting the same charm again leaves the previous charm alone.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch, ForceUnits: true})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.PreviousCharmURL(), gc.DeepEquals, s.charm.URL())
+
+	// Rolling back to the previous charm records the one rolled back from.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: s.charm})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.PreviousCharmURL(), gc.DeepEquals, sch.URL())
+}
+
 func (s *ApplicationSuite) TestSetCharmCharmSettings(c *gc.C) {
 	newCh := s.AddConfigCharm(c, "mysql", stringConfig, 2)
 	err := s.mysql.SetCharm(state.SetCharmConfig{
@@ -921,6 +943,24 @@ func (s *ApplicationSuite) TestUpdateConfigSettings(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestUpdateConfigSettingsReportsAllInvalidKeys(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := svc.UpdateConfigSettings(charm.Settings{
+		"foo":         "bar",
+		"skill-level": "profound",
+		"title":       "sir",
+	})
+	c.Assert(err, jc.Satisfies, state.IsInvalidConfigSettingsError)
+	c.Assert(err, gc.ErrorMatches, `invalid config settings: unknown option "foo"; option "skill-level" expected int, got "profound"`)
+	invalid := errors.Cause(err).(*state.ErrInvalidConfigSettings).Errors
+	c.Assert(invalid, gc.HasLen, 2)
+
+	// Nothing was written.
+	settings, err := svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{})
+}
+
 func assertNoSettingsRef(c *gc.C, st *state.State, svcName string, sch *state.Charm) {
 	_, err := state.ServiceSettingsRefCount(st, svcName, sch.URL())
 	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
@@ -1134,6 +1174,32 @@ func (s *ApplicationSuite) TestNewPeerRelationsAddedOnUpgrade(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestPeerRelations(c *gc.C) {
+	peers, err := s.mysql.PeerRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(peers, gc.HasLen, 0)
+
+	ch := s.AddMetaCharm(c, "mysql", mysqlBaseMeta+twoPeersMeta, 2)
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Relations with other applications are not peer relations.
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	peers, err = s.mysql.PeerRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	var keys []string
+	for _, rel := range peers {
+		keys = append(keys, rel.String())
+	}
+	sort.Strings(keys)
+	c.Assert(keys, jc.DeepEquals, []string{"mysql:cluster", "mysql:loadbalancer"})
+}
+
 func jujuInfoEp(applicationname string) state.Endpoint {
 	return state.Endpoint{
 		ApplicationName: applicationname,
@@ -1393,6 +1459,71 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestExposeEndpoints(c *gc.C) {
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+
+	endpoints := map[string][]network.PortRange{
+		"server": {{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+	}
+	err := s.mysql.ExposeEndpoints(endpoints)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, endpoints)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, endpoints)
+
+	// Exposing the whole application forgets the exposed endpoints.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+
+	// Clearing the exposed flag forgets the exposed endpoints.
+	err = s.mysql.ExposeEndpoints(endpoints)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestExposeEndpointsInvalid(c *gc.C) {
+	err := s.mysql.ExposeEndpoints(nil)
+	c.Assert(err, gc.ErrorMatches, `cannot expose endpoints of application "mysql": no endpoints specified`)
+
+	err = s.mysql.ExposeEndpoints(map[string][]network.PortRange{
+		"website": {{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose endpoints of application "mysql": application "mysql" has no "website" relation`)
+
+	err = s.mysql.ExposeEndpoints(map[string][]network.PortRange{
+		"server": {{FromPort: 3306, ToPort: 3306, Protocol: "icmp"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose endpoints of application "mysql": endpoint "server": invalid protocol "icmp", expected "tcp" or "udp"`)
+
+	err = s.mysql.ExposeEndpoints(map[string][]network.PortRange{"server": nil})
+	c.Assert(err, gc.ErrorMatches, `cannot expose endpoints of application "mysql": no port ranges specified for endpoint "server"`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestExposeEndpointsWhenNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ExposeEndpoints(map[string][]network.PortRange{
+		"server": {{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose endpoints of application "mysql": not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -1460,6 +1591,40 @@ func (s *ApplicationSuite) TestAddUnitWhenNotAlive(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": application "mysql" not found`)
 }
 
+func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
+	units, err := s.mysql.AddUnits(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 3)
+	for i, unit := range units {
+		c.Assert(unit.Name(), gc.Equals, fmt.Sprintf("mysql/%d", i))
+		c.Assert(unit.IsPrincipal(), jc.IsTrue)
+	}
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.GetApplicationUnitCount(s.mysql), gc.Equals, 3)
+
+	_, err = s.mysql.AddUnits(0)
+	c.Assert(err, gc.ErrorMatches, `cannot add units to application "mysql": unit count 0 not valid`)
+}
+
+func (s *ApplicationSuite) TestAddUnitsWhenNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.AddUnits(2)
+	c.Assert(err, gc.ErrorMatches, `cannot add units to application "mysql": application is not alive`)
+	units, err := s.mysql.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *ApplicationSuite) TestAddUnitsSubordinate(c *gc.C) {
+	logging := s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	_, err := logging.AddUnits(2)
+	c.Assert(err, gc.ErrorMatches, `cannot add units to application "logging": application is a subordinate`)
+}
+
 func (s *ApplicationSuite) TestReadUnit(c *gc.C) {
 	_, err := s.mysql.AddUnit()
 	c.Assert(err, jc.ErrorIsNil)
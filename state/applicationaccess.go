@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// applicationAccessKey returns the global key under which access to
+// the named application in the model is recorded.
+func applicationAccessKey(modelUUID, appName string) string {
+	return fmt.Sprintf("%s#%s", modelKey(modelUUID), applicationGlobalKey(appName))
+}
+
+// SetApplicationAccess sets the access the user has on the named
+// application, in addition to the access they have on the model as a
+// whole. Only permission.WriteAccess may be granted; setting
+// permission.NoAccess removes the grant. The user must already have
+// access to the model.
+func (st *State) SetApplicationAccess(user names.UserTag, appName string, access permission.Access) error {
+	if access != permission.WriteAccess && access != permission.NoAccess {
+		return errors.NotValidf("application access %q", access)
+	}
+	objectKey := applicationAccessKey(st.ModelUUID(), appName)
+	subjectKey := userGlobalKey(userAccessID(user))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := st.ApplicationAccess(user, appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current == access {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if access == permission.NoAccess {
+			return []txn.Op{removePermissionOp(objectKey, subjectKey)}, nil
+		}
+		app, err := st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", appName)
+		}
+		if _, err := st.modelUser(st.ModelUUID(), user); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      modelUsersC,
+			Id:     userAccessID(user),
+			Assert: txn.DocExists,
+		},
+			createPermissionOp(objectKey, subjectKey, access),
+		}, nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "setting access to application %q for user %q", appName, user.Id())
+}
+
+// ApplicationAccess returns the access the user has been granted on
+// the named application, not counting the access they have on the
+// model as a whole.
+func (st *State) ApplicationAccess(user names.UserTag, appName string) (permission.Access, error) {
+	objectKey := applicationAccessKey(st.ModelUUID(), appName)
+	perm, err := st.userPermission(objectKey, userGlobalKey(userAccessID(user)))
+	if errors.IsNotFound(err) {
+		return permission.NoAccess, nil
+	}
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// removeUserApplicationAccessOps returns the operations that remove the
+// access grants the user has on applications in the model, so that
+// they do not apply if the user is given access to the model again.
+func removeUserApplicationAccessOps(st *State, modelUUID string, user names.UserTag) ([]txn.Op, error) {
+	prefix := applicationAccessKey(modelUUID, "")
+	return removeApplicationAccessOpsMatching(st, bson.D{
+		{"object-global-key", bson.D{{"$regex", "^" + regexp.QuoteMeta(prefix)}}},
+		{"subject-global-key", userGlobalKey(userAccessID(user))},
+	})
+}
+
+// removeApplicationAccessOps returns the operations that remove every
+// access grant on the named application, so that they do not apply to
+// a later application with the same name.
+func removeApplicationAccessOps(st *State, appName string) ([]txn.Op, error) {
+	objectKey := applicationAccessKey(st.ModelUUID(), appName)
+	return removeApplicationAccessOpsMatching(st, bson.D{{"object-global-key", objectKey}})
+}
+
+func removeApplicationAccessOpsMatching(st *State, query bson.D) ([]txn.Op, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	err := permissions.Find(query).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      permissionsC,
+			Id:     doc.ID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationAccessSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ApplicationAccessSuite{})
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccess(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	user := s.Factory.MakeModelUser(c, nil)
+
+	access, err := s.State.ApplicationAccess(user.UserTag, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+
+	err = s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ApplicationAccess(user.UserTag, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	// Setting the same access again is a no-op.
+	err = s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.NoAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ApplicationAccess(user.UserTag, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccessInvalid(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	user := s.Factory.MakeModelUser(c, nil)
+	err := s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.AdminAccess)
+	c.Assert(err, gc.ErrorMatches, `application access "admin" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccessNotModelUser(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetApplicationAccess(user.UserTag(), "wordpress", permission.WriteAccess)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccessMissingApplication(c *gc.C) {
+	user := s.Factory.MakeModelUser(c, nil)
+	err := s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `setting access to application "wordpress" for user ".*": application "wordpress" not found`)
+}
+
+func (s *ApplicationAccessSuite) TestRemoveModelUserRemovesApplicationAccess(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	user := s.Factory.MakeModelUser(c, nil)
+	err := s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUserAccess(user.UserTag, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.ApplicationAccess(user.UserTag, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *ApplicationAccessSuite) TestRemoveApplicationRemovesApplicationAccess(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	user := s.Factory.MakeModelUser(c, nil)
+	err := s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	access, err := s.State.ApplicationAccess(user.UserTag, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccessDyingApplication(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err := app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	c.Assert(app.Life(), gc.Equals, state.Dying)
+
+	user := s.Factory.MakeModelUser(c, nil)
+	err = s.State.SetApplicationAccess(user.UserTag, "wordpress", permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `setting access to application "wordpress" for user ".*": application "wordpress" is not alive`)
+}
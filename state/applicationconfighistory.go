@@ -0,0 +1,239 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ConfigRevision describes the config settings of an application as
+// they were after a change to them was written.
+type ConfigRevision struct {
+	// Revision identifies the change. Revisions increase with every
+	// change made to the application's settings.
+	Revision int
+
+	// CharmURL is the URL of the charm the settings applied to.
+	CharmURL *charm.URL
+
+	// Settings holds all of the settings set on the application,
+	// excluding charm defaults.
+	Settings charm.Settings
+
+	// Author identifies who made the change, if known.
+	Author string
+
+	// Updated records when the change was made.
+	Updated time.Time
+}
+
+// configRevisionDoc is the persistent representation of a
+// ConfigRevision.
+type configRevisionDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	Revision    int    `bson:"revision"`
+	CharmURL    string `bson:"charmurl"`
+	Author      string `bson:"author,omitempty"`
+	Updated     int64  `bson:"updated"`
+
+	// Settings holds the recorded settings. Keys are escaped as
+	// they are in the settings collection.
+	Settings settingsMap `bson:"settings"`
+}
+
+func configRevisionID(appName string, revision int) string {
+	return fmt.Sprintf("%s#%d", appName, revision)
+}
+
+func configRevisionSequence(appName string) string {
+	return "confighistory-" + appName
+}
+
+// addConfigRevisionOp returns the operation that records the given
+// settings, for the given charm, as the next config revision of the
+// application.
+func (a *Application) addConfigRevisionOp(curl *charm.URL, author string, settings map[string]interface{}) (txn.Op, error) {
+	revision, err := a.st.sequence(configRevisionSequence(a.doc.Name))
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	docID := a.st.docID(configRevisionID(a.doc.Name, revision))
+	return txn.Op{
+		C:      configHistoryC,
+		Id:     docID,
+		Assert: txn.DocMissing,
+		Insert: &configRevisionDoc{
+			DocID:       docID,
+			Application: a.doc.Name,
+			Revision:    revision,
+			CharmURL:    curl.String(),
+			Settings:    copyMap(settings, escapeReplacer.Replace),
+			Author:      author,
+			Updated:     a.st.clock.Now().UnixNano(),
+		},
+	}, nil
+}
+
+// configHistoryCleanupBatchSize is the largest number of config
+// revisions removed in a single transaction by
+// cleanupApplicationConfigHistory.
+const configHistoryCleanupBatchSize = 1000
+
+// cleanupApplicationConfigHistory removes the config history of the
+// named, removed, application, a batch of revisions at a time.
+func (st *State) cleanupApplicationConfigHistory(appName string) error {
+	history, closer := st.getCollection(configHistoryC)
+	defer closer()
+
+	for {
+		var docs []struct {
+			DocID string `bson:"_id"`
+		}
+		err := history.Find(bson.D{{"application", appName}}).Select(
+			bson.D{{"_id", 1}},
+		).Limit(configHistoryCleanupBatchSize).All(&docs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		ops := make([]txn.Op, len(docs))
+		for i, doc := range docs {
+			ops[i] = txn.Op{
+				C:      configHistoryC,
+				Id:     doc.DocID,
+				Remove: true,
+			}
+		}
+		if err := st.runTransaction(ops); err != nil {
+			return errors.Annotatef(err, "cannot remove config history for application %q", appName)
+		}
+	}
+}
+
+// PruneConfigHistory removes the config revisions recorded before
+// now - maxHistoryTime. The latest revision of each application is
+// always kept, so the settings in use can still be identified.
+func PruneConfigHistory(st *State, maxHistoryTime time.Duration) error {
+	if maxHistoryTime <= 0 {
+		return errors.NotValidf("non-positive maxHistoryTime")
+	}
+	history, closer := st.getCollection(configHistoryC)
+	defer closer()
+
+	var docs []struct {
+		DocID       string `bson:"_id"`
+		Application string `bson:"application"`
+		Revision    int    `bson:"revision"`
+	}
+	t := st.clock.Now().Add(-maxHistoryTime)
+	err := history.Find(bson.D{{"updated", bson.M{"$lt": t.UnixNano()}}}).Select(
+		bson.D{{"_id", 1}, {"application", 1}, {"revision", 1}},
+	).All(&docs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	latest := make(map[string]int)
+	var ops []txn.Op
+	for _, doc := range docs {
+		if _, ok := latest[doc.Application]; !ok {
+			var last configRevisionDoc
+			err := history.Find(bson.D{{"application", doc.Application}}).Sort("-revision").One(&last)
+			if err != nil {
+				return errors.Annotatef(err, "cannot get latest config revision for application %q", doc.Application)
+			}
+			latest[doc.Application] = last.Revision
+		}
+		if doc.Revision == latest[doc.Application] {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      configHistoryC,
+			Id:     doc.DocID,
+			Remove: true,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Annotate(st.runTransaction(ops), "pruning config history")
+}
+
+// ConfigHistory returns the recorded revisions of the application's
+// config settings, oldest first.
+func (a *Application) ConfigHistory() ([]ConfigRevision, error) {
+	history, closer := a.st.getCollection(configHistoryC)
+	defer closer()
+
+	var docs []configRevisionDoc
+	err := history.Find(bson.D{{"application", a.doc.Name}}).Sort("revision").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get config history for application %q", a)
+	}
+	revisions := make([]ConfigRevision, len(docs))
+	for i, doc := range docs {
+		revisions[i], err = doc.configRevision()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return revisions, nil
+}
+
+func (doc *configRevisionDoc) configRevision() (ConfigRevision, error) {
+	curl, err := charm.ParseURL(doc.CharmURL)
+	if err != nil {
+		return ConfigRevision{}, errors.Annotatef(err, "config revision %d", doc.Revision)
+	}
+	return ConfigRevision{
+		Revision: doc.Revision,
+		CharmURL: curl,
+		Settings: charm.Settings(copyMap(doc.Settings, nil)),
+		Author:   doc.Author,
+		Updated:  time.Unix(0, doc.Updated).UTC(),
+	}, nil
+}
+
+// RevertConfig restores the application's config settings to those
+// recorded in the given revision. The revert is itself recorded as a
+// new revision. Only revisions recorded for the application's current
+// charm can be restored.
+func (a *Application) RevertConfig(revision int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot revert application %q config to revision %d", a, revision)
+	history, closer := a.st.getCollection(configHistoryC)
+	defer closer()
+
+	var doc configRevisionDoc
+	err = history.FindId(configRevisionID(a.doc.Name, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("config revision %d", revision)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.CharmURL != a.doc.CharmURL.String() {
+		return errors.Errorf("revision was recorded for charm %q, not %q", doc.CharmURL, a.doc.CharmURL)
+	}
+	current, err := a.ConfigSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes := make(charm.Settings)
+	for name := range current {
+		changes[name] = nil
+	}
+	for name, value := range doc.Settings {
+		changes[name] = value
+	}
+	return a.updateConfigSettings("", changes)
+}
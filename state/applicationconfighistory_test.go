@@ -0,0 +1,212 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type ConfigHistorySuite struct {
+	ConnSuite
+	charm *state.Charm
+	app   *state.Application
+}
+
+var _ = gc.Suite(&ConfigHistorySuite{})
+
+func (s *ConfigHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "dummy")
+	s.app = s.AddTestingService(c, "dummy", s.charm)
+}
+
+func (s *ConfigHistorySuite) TestConfigHistoryEmpty(c *gc.C) {
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *ConfigHistorySuite) TestUpdateConfigSettingsRecordsRevisions(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateConfigSettingsBy("user-bob", charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	// A change that makes no difference is not recorded.
+	err = s.app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Revision < history[1].Revision, jc.IsTrue)
+	c.Assert(history[0].CharmURL, gc.DeepEquals, s.charm.URL())
+	c.Assert(history[0].Settings, gc.DeepEquals, charm.Settings{"title": "sir"})
+	c.Assert(history[0].Author, gc.Equals, "")
+	c.Assert(history[0].Updated.IsZero(), jc.IsFalse)
+	c.Assert(history[1].Settings, gc.DeepEquals, charm.Settings{"title": "sir", "outlook": "positive"})
+	c.Assert(history[1].Author, gc.Equals, "user-bob")
+}
+
+func (s *ConfigHistorySuite) TestRevertConfig(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateConfigSettings(charm.Settings{"title": nil, "outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+
+	err = s.app.RevertConfig(history[0].Revision)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"title": "sir"})
+
+	// The revert itself is recorded.
+	history, err = s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[2].Settings, gc.DeepEquals, charm.Settings{"title": "sir"})
+}
+
+func (s *ConfigHistorySuite) TestRevertConfigUnknownRevision(c *gc.C) {
+	err := s.app.RevertConfig(42)
+	c.Assert(err, gc.ErrorMatches, `cannot revert application "dummy" config to revision 42: config revision 42 not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ConfigHistorySuite) TestRevertConfigDifferentCharm(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCharm := s.AddConfigCharm(c, "dummy", dummyConfig, 2)
+	err = s.app.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.RevertConfig(history[0].Revision)
+	c.Assert(err, gc.ErrorMatches, `cannot revert application "dummy" config to revision .*: revision was recorded for charm ".*-dummy-1", not ".*-dummy-2"`)
+}
+
+func (s *ConfigHistorySuite) TestSetCharmRecordsRevision(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"title": "sir", "username": "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCharm := s.AddConfigCharm(c, "dummy", dummyConfig, 2)
+	err = s.app.SetCharm(state.SetCharmConfig{
+		Charm:          newCharm,
+		ConfigSettings: charm.Settings{"outlook": "positive"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The settings the new charm lacks are not carried over.
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[1].CharmURL, gc.DeepEquals, newCharm.URL())
+	c.Assert(history[1].Settings, gc.DeepEquals, charm.Settings{"title": "sir", "outlook": "positive"})
+
+	err = s.app.RevertConfig(history[1].Revision)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := s.app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"title": "sir", "outlook": "positive"})
+}
+
+func (s *ConfigHistorySuite) TestConfigHistoryEscapesKeys(c *gc.C) {
+	ch := s.AddConfigCharm(c, "dummy", dottedConfig, 3)
+	app := s.AddTestingService(c, "dotted", ch)
+	err := app.UpdateConfigSettings(charm.Settings{"foo.bar": "baz", "$dollar": "cash"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Settings, gc.DeepEquals, charm.Settings{"foo.bar": "baz", "$dollar": "cash"})
+}
+
+func (s *ConfigHistorySuite) TestPruneConfigHistory(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateConfigSettings(charm.Settings{"title": "madam"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(2 * time.Hour)
+	err = s.app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	other := s.AddTestingService(c, "other", s.charm)
+	err = other.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(2 * time.Hour)
+
+	err = state.PruneConfigHistory(s.State, 3*time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Recent revisions, and the latest revision of each application,
+	// remain.
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Settings, gc.DeepEquals, charm.Settings{"title": "madam", "outlook": "positive"})
+	history, err = other.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+}
+
+func (s *ConfigHistorySuite) TestConfigHistoryRemovedByCleanup(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The history outlives the application until the cleanup runs.
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	history, err = s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *ConfigHistorySuite) TestSetCharmRecordsAuthor(c *gc.C) {
+	newCharm := s.AddConfigCharm(c, "dummy", dummyConfig, 2)
+	err := s.app.SetCharm(state.SetCharmConfig{
+		Charm:  newCharm,
+		Author: "user-bob",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Author, gc.Equals, "user-bob")
+}
+
+func (s *ConfigHistorySuite) TestPruneConfigHistoryInvalid(c *gc.C) {
+	err := state.PruneConfigHistory(s.State, 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+const dummyConfig = `
+options:
+  title: {default: My Title, description: A title., type: string}
+  outlook: {description: An outlook., type: string}
+`
+
+const dottedConfig = `
+options:
+  foo.bar: {description: A dotted option., type: string}
+  $dollar: {description: A dollar option., type: string}
+`
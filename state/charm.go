@@ -582,6 +582,53 @@ func (st *State) AllCharms() ([]*Charm, error) {
 	return charms, errors.Trace(iter.Close())
 }
 
+// PurgeUnusedCharms destroys and removes every uploaded charm that is
+// not referenced by any application or unit, returning the URLs of the
+// charms removed. Charms that are still in use, or that an application
+// was upgraded from and may be rolled back to, are left untouched.
+func (st *State) PurgeUnusedCharms() ([]*charm.URL, error) {
+	charms, err := st.AllCharms()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var removed []*charm.URL
+	for _, ch := range charms {
+		if ch.IsPlaceholder() || !ch.IsUploaded() {
+			continue
+		}
+		if previous, err := st.isPreviousCharm(ch.URL()); err != nil {
+			return removed, errors.Trace(err)
+		} else if previous {
+			continue
+		}
+		err := ch.Destroy()
+		switch errors.Cause(err) {
+		case nil:
+		case errCharmInUse:
+			continue
+		default:
+			return removed, errors.Annotatef(err, "destroying charm %q", ch)
+		}
+		if err := ch.Remove(); err != nil {
+			return removed, errors.Annotatef(err, "removing charm %q", ch)
+		}
+		removed = append(removed, ch.URL())
+	}
+	return removed, nil
+}
+
+// isPreviousCharm reports whether any application was upgraded from
+// the charm with the given URL, and so still needs it to roll back.
+func (st *State) isPreviousCharm(curl *charm.URL) (bool, error) {
+	applications, closer := st.getCollection(applicationsC)
+	defer closer()
+	count, err := applications.Find(bson.D{{"previous-charmurl", curl}}).Count()
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot check previous charm %q", curl)
+	}
+	return count > 0, nil
+}
+
 // Charm returns the charm with the given URL. Charms pending upload
 // to storage and placeholders are never returned.
 func (st *State) Charm(curl *charm.URL) (*Charm, error) {
@@ -227,6 +227,51 @@ func (s *CharmSuite) TestDestroyUnreferencedCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *CharmSuite) TestPurgeUnusedCharms(c *gc.C) {
+	used := s.AddTestingCharm(c, "mysql")
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: used,
+	})
+
+	removed, err := s.State.PurgeUnusedCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, jc.DeepEquals, []*charm.URL{s.curl})
+	s.checkRemoved(c)
+
+	_, err = s.State.Charm(used.URL())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing more to do.
+	removed, err = s.State.PurgeUnusedCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.HasLen, 0)
+}
+
+func (s *CharmSuite) TestPurgeUnusedCharmsKeepsPreviousCharm(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.charm,
+	})
+	info := s.dummyCharm(c, "cs:quantal/dummy-2")
+	newCh, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetCharm(state.SetCharmConfig{Charm: newCh})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Neither the cleanup queued by the upgrade nor a purge removes
+	// the charm the application was upgraded from.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	removed, err := s.State.PurgeUnusedCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.HasLen, 0)
+
+	// So the upgrade can still be rolled back.
+	_, err = s.State.Charm(s.curl)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetCharm(state.SetCharmConfig{Charm: s.charm})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *CharmSuite) TestDestroyUnitReferencedCharm(c *gc.C) {
 	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Charm: s.charm,
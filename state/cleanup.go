@@ -33,6 +33,7 @@ const (
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
 	cleanupVolumesForDyingModel          cleanupKind = "modelVolumes"
 	cleanupFilesystemsForDyingModel      cleanupKind = "modelFilesystems"
+	cleanupApplicationConfigHistory      cleanupKind = "applicationConfigHistory"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -113,6 +114,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupVolumesForDyingModel()
 		case cleanupFilesystemsForDyingModel:
 			err = st.cleanupFilesystemsForDyingModel()
+		case cleanupApplicationConfigHistory:
+			err = st.cleanupApplicationConfigHistory(doc.Prefix)
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
@@ -343,6 +346,12 @@ func (st *State) cleanupCharm(charmURL string) error {
 	} else if err != nil {
 		return errors.Annotate(err, "reading charm")
 	}
+	if previous, err := st.isPreviousCharm(curl); err != nil {
+		return errors.Trace(err)
+	} else if previous {
+		// Kept so that the upgrade from it can be rolled back.
+		return nil
+	}
 
 	err = ch.Destroy()
 	switch errors.Cause(err) {
@@ -369,6 +378,19 @@ func (st *State) cleanupDyingUnit(name string) error {
 	} else if err != nil {
 		return err
 	}
+	// Destroy the unit's subordinates, so that they are not left
+	// orphaned on the machine once their principal has gone.
+	for _, subName := range unit.SubordinateNames() {
+		subUnit, err := st.Unit(subName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := subUnit.Destroy(); err != nil {
+			return err
+		}
+	}
 	// Mark the unit as departing from its joined relations, allowing
 	// related units to start converging to a state in which that unit
 	// is gone as quickly as possible.
@@ -472,6 +472,50 @@ func (s *CleanupSuite) TestCleanupDyingUnit(c *gc.C) {
 	assertRemoved(c, prr.rel)
 }
 
+func (s *CleanupSuite) TestCleanupDyingUnitDestroysSubordinates(c *gc.C) {
+	// Create a principal unit with two subordinates.
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	principal, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = principal.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	var subordinates []*state.Unit
+	for _, name := range []string{"logging", "monitoring"} {
+		s.AddTestingService(c, name, s.AddTestingCharm(c, name))
+		eps, err := s.State.InferEndpoints(name+":info", "wordpress:juju-info")
+		c.Assert(err, jc.ErrorIsNil)
+		rel, err := s.State.AddRelation(eps...)
+		c.Assert(err, jc.ErrorIsNil)
+		ru, err := rel.Unit(principal)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		subordinate, err := s.State.Unit(name + "/0")
+		c.Assert(err, jc.ErrorIsNil)
+		subordinates = append(subordinates, subordinate)
+	}
+	err = principal.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(principal.SubordinateNames(), gc.HasLen, 2)
+
+	// Destroy the principal; the subordinates are unaffected until
+	// the cleanup runs...
+	err = principal.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, principal, state.Dying)
+	for _, subordinate := range subordinates {
+		assertLife(c, subordinate, state.Alive)
+	}
+
+	// ...at which point they're all Dying.
+	s.assertCleanupRuns(c)
+	for _, subordinate := range subordinates {
+		assertLife(c, subordinate, state.Dying)
+	}
+}
+
 func (s *CleanupSuite) TestCleanupDyingUnitAlreadyRemoved(c *gc.C) {
 	// Create active unit, in a relation.
 	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -57,6 +58,47 @@ func onAbort(txnErr, err error) error {
 	return errors.Trace(txnErr)
 }
 
+// ErrInvalidConfigSettings is returned when application config settings
+// fail validation against the charm's config schema. It records the
+// validation error for every offending key, so that they can all be
+// fixed at once.
+type ErrInvalidConfigSettings struct {
+	// Errors holds the validation error for each invalid key.
+	Errors map[string]error
+}
+
+func (e *ErrInvalidConfigSettings) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 1 {
+		return e.Errors[keys[0]].Error()
+	}
+	messages := make([]string, len(keys))
+	for i, key := range keys {
+		messages[i] = e.Errors[key].Error()
+	}
+	return fmt.Sprintf("invalid config settings: %s", strings.Join(messages, "; "))
+}
+
+// IsInvalidConfigSettingsError returns if the given error or its cause
+// is ErrInvalidConfigSettings.
+func IsInvalidConfigSettingsError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrInvalidConfigSettings)
+	return ok
+}
+
 // ErrProviderIDNotUnique is a standard error to indicate the value specified
 // for a ProviderID field is not unique within the current model.
 type ErrProviderIDNotUnique struct {
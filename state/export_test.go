@@ -362,6 +362,10 @@ func GetUnitModelUUID(unit *Unit) string {
 	return unit.doc.ModelUUID
 }
 
+func GetApplicationUnitCount(app *Application) int {
+	return app.doc.UnitCount
+}
+
 func GetCollection(st *State, name string) (mongo.Collection, func()) {
 	return st.getCollection(name)
 }
@@ -0,0 +1,340 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+const groupGlobalKeyPrefix = "gr"
+
+func groupGlobalKey(name string) string {
+	return fmt.Sprintf("%s#%s", groupGlobalKeyPrefix, name)
+}
+
+var validGroupName = regexp.MustCompile(`^[a-z0-9][a-z0-9.+-]*$`)
+
+// IsValidGroupName returns whether name is a valid group name.
+func IsValidGroupName(name string) bool {
+	return validGroupName.MatchString(name)
+}
+
+// groupDoc represents a group of users in the database.
+type groupDoc struct {
+	DocID       string    `bson:"_id"`
+	Members     []string  `bson:"members"`
+	CreatedBy   string    `bson:"createdby"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+// Group represents a named set of users that can be granted
+// access as a whole.
+type Group struct {
+	st  *State
+	doc groupDoc
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.doc.DocID
+}
+
+// Members returns the users that belong to the group.
+func (g *Group) Members() []names.UserTag {
+	members := make([]names.UserTag, len(g.doc.Members))
+	for i, member := range g.doc.Members {
+		members[i] = names.NewUserTag(member)
+	}
+	return members
+}
+
+// CreatedBy returns the name of the user that created the group.
+func (g *Group) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// DateCreated returns when the group was created in UTC.
+func (g *Group) DateCreated() time.Time {
+	return g.doc.DateCreated.UTC()
+}
+
+// Refresh refreshes information about the group from the state.
+func (g *Group) Refresh() error {
+	group, err := g.st.Group(g.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	g.doc = group.doc
+	return nil
+}
+
+// AddGroup adds a group with no members to the database.
+func (st *State) AddGroup(name string, createdBy names.UserTag) (*Group, error) {
+	if !IsValidGroupName(name) {
+		return nil, errors.NotValidf("group name %q", name)
+	}
+	group := &Group{
+		st: st,
+		doc: groupDoc{
+			DocID:       name,
+			Members:     []string{},
+			CreatedBy:   createdBy.Id(),
+			DateCreated: st.NowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      groupsC,
+		Id:     name,
+		Assert: txn.DocMissing,
+		Insert: &group.doc,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("group %q", name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// Group returns the group with the given name.
+func (st *State) Group(name string) (*Group, error) {
+	groups, closer := st.getCollection(groupsC)
+	defer closer()
+
+	group := &Group{st: st}
+	err := groups.FindId(name).One(&group.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("group %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get group %q", name)
+	}
+	return group, nil
+}
+
+// RemoveGroup removes the named group, and with it its memberships and
+// every access grant made to it, so that none of them apply to a later
+// group with the same name.
+func (st *State) RemoveGroup(name string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.Group(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      groupsC,
+			Id:     name,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		permissionOps, err := st.removeGroupPermissionOps(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, permissionOps...), nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "removing group %q", name)
+}
+
+// removeGroupPermissionOps returns the operations that remove the
+// access the named group has on any model or the controller.
+func (st *State) removeGroupPermissionOps(name string) ([]txn.Op, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	query := bson.D{{"subject-global-key", groupGlobalKey(name)}}
+	if err := permissions.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      permissionsC,
+			Id:     doc.ID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// AddGroupMember adds the user to the named group. Adding a user that
+// is already a member has no effect.
+func (st *State) AddGroupMember(name string, user names.UserTag) error {
+	if user.IsLocal() {
+		if _, err := st.User(user); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return st.updateGroupMembers(name, bson.D{{"$addToSet", bson.D{{"members", groupMemberID(user)}}}})
+}
+
+// RemoveGroupMember removes the user from the named group. Removing a
+// user that is not a member has no effect.
+func (st *State) RemoveGroupMember(name string, user names.UserTag) error {
+	return st.updateGroupMembers(name, bson.D{{"$pull", bson.D{{"members", groupMemberID(user)}}}})
+}
+
+func (st *State) updateGroupMembers(name string, update bson.D) error {
+	ops := []txn.Op{{
+		C:      groupsC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("group %q", name)
+	}
+	return errors.Trace(err)
+}
+
+// removeUserGroupMembershipOps returns the operations that remove the
+// user from every group they belong to.
+func (st *State) removeUserGroupMembershipOps(user names.UserTag) ([]txn.Op, error) {
+	groups, closer := st.getCollection(groupsC)
+	defer closer()
+
+	memberID := groupMemberID(user)
+	var docs []groupDoc
+	err := groups.Find(bson.D{{"members", memberID}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      groupsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$pull", bson.D{{"members", memberID}}}},
+		}
+	}
+	return ops, nil
+}
+
+// groupMemberID returns the id under which the user is recorded as a
+// member of a group.
+func groupMemberID(user names.UserTag) string {
+	return strings.ToLower(user.Id())
+}
+
+// accessObjectGlobalKey returns the global key of the model or
+// controller that is the target of an access grant.
+func (st *State) accessObjectGlobalKey(target names.Tag) (string, error) {
+	switch target.Kind() {
+	case names.ModelTagKind:
+		return modelKey(target.Id()), nil
+	case names.ControllerTagKind:
+		return controllerKey(st.ControllerUUID()), nil
+	}
+	return "", errors.NotValidf("%q as a target", target.Kind())
+}
+
+// SetGroupAccess sets the access the members of the named group have
+// on the target, which must be a model or the controller. Setting
+// permission.NoAccess removes the group's access.
+func (st *State) SetGroupAccess(name string, target names.Tag, access permission.Access) error {
+	if access != permission.NoAccess {
+		var err error
+		switch target.Kind() {
+		case names.ModelTagKind:
+			err = permission.ValidateModelAccess(access)
+		case names.ControllerTagKind:
+			err = permission.ValidateControllerAccess(access)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	objectKey, err := st.accessObjectGlobalKey(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	subjectKey := groupGlobalKey(name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.Group(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      groupsC,
+			Id:     name,
+			Assert: txn.DocExists,
+		}}
+		current, err := st.GroupAccess(name, target)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch {
+		case current == access:
+			return nil, jujutxn.ErrNoOperations
+		case access == permission.NoAccess:
+			ops = append(ops, removePermissionOp(objectKey, subjectKey))
+		case current == permission.NoAccess:
+			ops = append(ops, createPermissionOp(objectKey, subjectKey, access))
+		default:
+			ops = append(ops, updatePermissionOp(objectKey, subjectKey, access))
+		}
+		return ops, nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "setting access for group %q", name)
+}
+
+// GroupAccess returns the access the members of the named group have
+// on the target, which must be a model or the controller.
+func (st *State) GroupAccess(name string, target names.Tag) (permission.Access, error) {
+	objectKey, err := st.accessObjectGlobalKey(target)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	perm, err := st.userPermission(objectKey, groupGlobalKey(name))
+	if errors.IsNotFound(err) {
+		return permission.NoAccess, nil
+	}
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// UserGroupAccess returns the greatest access that any of the groups
+// the user belongs to have on the target, which must be a model or
+// the controller.
+func (st *State) UserGroupAccess(user names.UserTag, target names.Tag) (permission.Access, error) {
+	groups, closer := st.getCollection(groupsC)
+	defer closer()
+
+	var docs []groupDoc
+	err := groups.Find(bson.D{{"members", groupMemberID(user)}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	result := permission.NoAccess
+	for _, doc := range docs {
+		access, err := st.GroupAccess(doc.DocID, target)
+		if err != nil {
+			return permission.NoAccess, errors.Trace(err)
+		}
+		if target.Kind() == names.ModelTagKind && access.GreaterModelAccessThan(result) ||
+			target.Kind() == names.ControllerTagKind && access.GreaterControllerAccessThan(result) {
+			result = access
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing/factory"
+)
+
+type GroupSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&GroupSuite{})
+
+func (s *GroupSuite) TestAddGroup(c *gc.C) {
+	now := s.State.NowToTheSecond()
+	group, err := s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+	c.Assert(group.Members(), gc.HasLen, 0)
+	c.Assert(group.CreatedBy(), gc.Equals, s.Owner.Id())
+	c.Assert(group.DateCreated().Before(now), jc.IsFalse)
+
+	group, err = s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+}
+
+func (s *GroupSuite) TestAddGroupInvalidName(c *gc.C) {
+	_, err := s.State.AddGroup("Devs!", s.Owner)
+	c.Assert(err, gc.ErrorMatches, `group name "Devs!" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *GroupSuite) TestAddGroupDuplicate(c *gc.C) {
+	_, err := s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, gc.ErrorMatches, `group "devs" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *GroupSuite) TestGroupNotFound(c *gc.C) {
+	_, err := s.State.Group("devs")
+	c.Assert(err, gc.ErrorMatches, `group "devs" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *GroupSuite) TestGroupMembers(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	group, err := s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddGroupMember("devs", bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddGroupMember("devs", bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddGroupMember("devs", names.NewUserTag("mary@external"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Refresh(), jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{
+		bob.UserTag(), names.NewUserTag("mary@external"),
+	})
+
+	err = s.State.RemoveGroupMember("devs", bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Refresh(), jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{names.NewUserTag("mary@external")})
+}
+
+func (s *GroupSuite) TestAddGroupMemberMissingUser(c *gc.C) {
+	_, err := s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddGroupMember("devs", names.NewUserTag("bob"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *GroupSuite) TestAddGroupMemberMissingGroup(c *gc.C) {
+	err := s.State.AddGroupMember("devs", s.Owner)
+	c.Assert(err, gc.ErrorMatches, `group "devs" not found`)
+}
+
+func (s *GroupSuite) TestSetGroupAccess(c *gc.C) {
+	_, err := s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+
+	for _, expect := range []permission.Access{
+		permission.WriteAccess,
+		permission.ReadAccess,
+		permission.NoAccess,
+	} {
+		err = s.State.SetGroupAccess("devs", s.State.ModelTag(), expect)
+		c.Assert(err, jc.ErrorIsNil)
+		access, err = s.State.GroupAccess("devs", s.State.ModelTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(access, gc.Equals, expect)
+	}
+}
+
+func (s *GroupSuite) TestSetGroupAccessInvalid(c *gc.C) {
+	_, err := s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.SuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, `.*"superuser".*`)
+}
+
+func (s *GroupSuite) TestUserGroupAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	for _, name := range []string{"devs", "ops"} {
+		_, err := s.State.AddGroup(name, s.Owner)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.AddGroupMember(name, bob.UserTag())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	access, err := s.State.UserGroupAccess(bob.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("ops", s.State.ModelTag(), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.UserGroupAccess(bob.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+
+	err = s.State.RemoveGroupMember("ops", bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.UserGroupAccess(bob.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+}
+
+func (s *GroupSuite) TestRemoveUserRemovesGroupMemberships(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	mary := s.Factory.MakeUser(c, &factory.UserParams{Name: "mary", NoModelUser: true})
+	for _, name := range []string{"devs", "ops"} {
+		_, err := s.State.AddGroup(name, s.Owner)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.AddGroupMember(name, bob.UserTag())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.State.AddGroupMember("devs", mary.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUser(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	devs, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devs.Members(), jc.DeepEquals, []names.UserTag{mary.UserTag()})
+	ops, err := s.State.Group("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops.Members(), gc.HasLen, 0)
+}
+
+func (s *GroupSuite) TestRemoveGroup(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	for _, name := range []string{"devs", "ops"} {
+		_, err := s.State.AddGroup(name, s.Owner)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.AddGroupMember(name, bob.UserTag())
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.SetGroupAccess(name, s.State.ModelTag(), permission.WriteAccess)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.State.SetGroupAccess("devs", otherSt.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ControllerTag(), permission.LoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Group("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// A new group with the same name has no members and no access.
+	_, err = s.State.AddGroup("devs", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, target := range []names.Tag{s.State.ModelTag(), otherSt.ModelTag(), s.State.ControllerTag()} {
+		access, err := s.State.GroupAccess("devs", target)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(access, gc.Equals, permission.NoAccess)
+	}
+	access, err := s.State.UserGroupAccess(bob.UserTag(), otherSt.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+
+	// Other groups are untouched.
+	access, err = s.State.GroupAccess("ops", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *GroupSuite) TestRemoveGroupNotFound(c *gc.C) {
+	err := s.State.RemoveGroup("devs")
+	c.Assert(err, gc.ErrorMatches, `removing group "devs": group "devs" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
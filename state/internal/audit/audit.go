@@ -4,8 +4,11 @@
 package audit
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
@@ -64,6 +67,75 @@ func PutAuditEntryFn(
 	}
 }
 
+// Iterator iterates over audit entry documents. *mgo.Iter satisfies
+// this interface.
+type Iterator interface {
+	Next(result interface{}) bool
+	Close() error
+}
+
+// GetAuditEntriesFn creates a closure which when passed an audit.Filter
+// will return the matching entries in the audit collection, newest
+// first. iterDocs must return an iterator over the documents in the
+// named collection that match the query, newest first.
+func GetAuditEntriesFn(
+	collectionName string,
+	iterDocs func(string, bson.D) Iterator,
+) func(audit.Filter) ([]audit.AuditEntry, error) {
+	return func(filter audit.Filter) ([]audit.AuditEntry, error) {
+		query := bson.D{}
+		if filter.OriginName != "" {
+			query = append(query, bson.DocElem{"origin-name", filter.OriginName})
+		}
+		iter := iterDocs(collectionName, query)
+		var entries []audit.AuditEntry
+		var doc auditEntryDoc
+		for iter.Next(&doc) {
+			entry, err := auditEntryFromAuditEntryDoc(doc)
+			if err != nil {
+				iter.Close()
+				return nil, errors.Trace(err)
+			}
+			// Timestamps are stored as text that does not sort in
+			// time order, so the time constraints are checked here.
+			// As entries arrive newest first, we can stop as soon as
+			// one is too old.
+			if !filter.After.IsZero() && entry.Timestamp.Before(filter.After) {
+				break
+			}
+			if !filter.Matches(entry) {
+				continue
+			}
+			entries = append(entries, entry)
+			if filter.Limit > 0 && len(entries) == filter.Limit {
+				break
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return entries, nil
+	}
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotatef(err, "cannot parse timestamp %q", doc.Timestamp)
+	}
+
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
+
 func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, error) {
 
 	timeAsBlob, err := auditEntry.Timestamp.MarshalText()
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -111,3 +113,103 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestGetAuditEntries_FiltersNewestFirst(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	base := coretesting.NonZeroTime().UTC()
+	newEntry := func(origin string, age time.Duration) audit.AuditEntry {
+		return audit.AuditEntry{
+			JujuServerVersion: version.MustParse("1.0.0"),
+			ModelUUID:         modelUUID,
+			Timestamp:         base.Add(-age),
+			RemoteAddress:     "8.8.8.8",
+			OriginType:        "API request",
+			OriginName:        origin,
+			Operation:         "Client:v1 - FullStatus",
+			Data:              map[string]interface{}{"a.b": "c"},
+		}
+	}
+	// The entries are listed newest first, as the iterator must
+	// return them.
+	entries := []audit.AuditEntry{
+		newEntry("user-bob", 0),
+		newEntry("user-mary", time.Minute),
+		newEntry("user-bob", 2*time.Minute),
+		newEntry("user-bob", 3*time.Minute),
+	}
+	var docs []interface{}
+	putAuditEntry := stateaudit.PutAuditEntryFn("audit.log", func(_ string, d ...interface{}) error {
+		docs = append(docs, d...)
+		return nil
+	})
+	for _, entry := range entries {
+		c.Assert(putAuditEntry(entry), jc.ErrorIsNil)
+	}
+
+	var queries []bson.D
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", func(collectionName string, query bson.D) stateaudit.Iterator {
+		c.Check(collectionName, gc.Equals, "audit.log")
+		queries = append(queries, query)
+		var matching []interface{}
+		for i, doc := range docs {
+			if len(query) == 0 || entries[i].OriginName == query[0].Value {
+				matching = append(matching, doc)
+			}
+		}
+		return &fakeIterator{docs: matching}
+	})
+
+	result, err := getAuditEntries(audit.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, entries)
+
+	result, err = getAuditEntries(audit.Filter{
+		OriginName: "user-bob",
+		After:      base.Add(-150 * time.Second),
+		Before:     base,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, entries[2:3])
+
+	result, err = getAuditEntries(audit.Filter{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, entries[:2])
+
+	c.Check(queries, jc.DeepEquals, []bson.D{{}, {{"origin-name", "user-bob"}}, {}})
+}
+
+func (*AuditSuite) TestGetAuditEntries_PropagatesReadError(c *gc.C) {
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", func(string, bson.D) stateaudit.Iterator {
+		return &fakeIterator{err: errors.New("my error")}
+	})
+	_, err := getAuditEntries(audit.Filter{})
+	c.Check(err, gc.ErrorMatches, "my error")
+}
+
+// fakeIterator implements stateaudit.Iterator by round-tripping the
+// given documents through BSON.
+type fakeIterator struct {
+	docs []interface{}
+	err  error
+}
+
+func (it *fakeIterator) Next(result interface{}) bool {
+	if len(it.docs) == 0 {
+		return false
+	}
+	data, err := bson.Marshal(it.docs[0])
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.docs = it.docs[1:]
+	if err := bson.Unmarshal(data, result); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *fakeIterator) Close() error {
+	return it.err
+}
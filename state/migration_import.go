@@ -285,7 +285,8 @@ func (i *importer) modelUsers() error {
 			user.CreatedBy(),
 			user.DisplayName(),
 			user.DateCreated(),
-			permission.Access(user.Access()))...,
+			permission.Access(user.Access()),
+			nil)...,
 		)
 	}
 	if err := i.st.runTransaction(ops); err != nil {
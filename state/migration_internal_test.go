@@ -98,6 +98,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		// Groups, like users, are controller global and not migrated.
+		groupsC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
@@ -176,6 +178,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// phase after the initial model migration.
 		charmsC,
 
+		// Application config history is an audit aid for the source
+		// model; the current settings are migrated with the application.
+		configHistoryC,
+
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
@@ -237,6 +243,9 @@ func (s *MigrationSuite) TestUserAccessDocFields(c *gc.C) {
 		// ObjectUUID shouldn't be exported, and is inherited
 		// from the model definition.
 		"ObjectUUID",
+		// Expires is not migrated until the description package
+		// can carry it.
+		"Expires",
 		// Tracked fields:
 		"UserName",
 		"DisplayName",
@@ -349,6 +358,10 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedEndpoints and PreviousCharmURL are not migrated until
+		// the description package can carry them.
+		"ExposedEndpoints",
+		"PreviousCharmURL",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelOperation is a high-level model operation, encapsulating the
+// logic required to apply a change to a model. Several operations may
+// be combined with ComposeModelOperations and applied in a single
+// transaction, so that multi-step flows either succeed or fail as a
+// whole.
+type ModelOperation interface {
+	// Build builds the low-level database transaction operations
+	// required to apply the change. If the transaction operations
+	// fail (e.g. due to concurrent changes), then Build may be called
+	// again. The attempt number, starting at zero, is passed in.
+	//
+	// Build is treated as a jujutxn.TransactionSource, so the errors
+	// in the jujutxn package may be returned by Build to influence
+	// transaction execution.
+	Build(attempt int) ([]txn.Op, error)
+
+	// Done is called after the operation is run, whether it succeeds
+	// or not. The result of running the operation is passed in, and
+	// the Done method may annotate the error, or run additional
+	// non-transactional logic depending on the outcome.
+	Done(error) error
+}
+
+// ApplyOperation applies the given ModelOperation to the model.
+func (st *State) ApplyOperation(op ModelOperation) error {
+	err := st.run(op.Build)
+	return op.Done(err)
+}
+
+// ComposeModelOperations returns a ModelOperation that builds the
+// transaction operations of all the given operations, in order, so
+// that they are applied together in a single transaction.
+func ComposeModelOperations(ops ...ModelOperation) ModelOperation {
+	return compositeModelOperation(ops)
+}
+
+type compositeModelOperation []ModelOperation
+
+// Build is part of the ModelOperation interface.
+func (c compositeModelOperation) Build(attempt int) ([]txn.Op, error) {
+	var result []txn.Op
+	for _, op := range c {
+		ops, err := op.Build(attempt)
+		if err == jujutxn.ErrNoOperations {
+			continue
+		} else if err != nil {
+			// Not traced, so that jujutxn errors are
+			// recognised by the transaction runner.
+			return nil, err
+		}
+		result = append(result, ops...)
+	}
+	if len(result) == 0 {
+		return nil, jujutxn.ErrNoOperations
+	}
+	return result, nil
+}
+
+// Done is part of the ModelOperation interface. It calls Done on
+// each of the composed operations, in order, threading the error
+// returned by each into the next.
+func (c compositeModelOperation) Done(err error) error {
+	for _, op := range c {
+		err = op.Done(err)
+	}
+	return err
+}
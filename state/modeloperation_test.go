@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	jujutxn "github.com/juju/txn"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state"
+)
+
+type ModelOperationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelOperationSuite{})
+
+func (s *ModelOperationSuite) TestApplyOperationBuildError(c *gc.C) {
+	op := &testModelOperation{buildErr: errors.New("bad build")}
+	err := s.State.ApplyOperation(op)
+	c.Assert(err, gc.ErrorMatches, "done: bad build")
+	c.Assert(op.attempts, gc.Equals, 1)
+}
+
+func (s *ModelOperationSuite) TestApplyOperationNoOperations(c *gc.C) {
+	op := &testModelOperation{buildErr: jujutxn.ErrNoOperations}
+	err := s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.done, jc.IsTrue)
+}
+
+func (s *ModelOperationSuite) TestComposeModelOperations(c *gc.C) {
+	op1 := &testModelOperation{buildErr: jujutxn.ErrNoOperations}
+	op2 := &testModelOperation{buildErr: errors.New("bad build")}
+	op3 := &testModelOperation{}
+	err := s.State.ApplyOperation(state.ComposeModelOperations(op1, op2, op3))
+	c.Assert(err, gc.ErrorMatches, "done: done: done: bad build")
+	c.Assert(op1.attempts, gc.Equals, 1)
+	c.Assert(op2.attempts, gc.Equals, 1)
+	// The third operation is never built, but is still told the outcome.
+	c.Assert(op3.attempts, gc.Equals, 0)
+	c.Assert(op3.done, jc.IsTrue)
+}
+
+func (s *ModelOperationSuite) TestComposeModelOperationsNoOperations(c *gc.C) {
+	op1 := &testModelOperation{buildErr: jujutxn.ErrNoOperations}
+	op2 := &testModelOperation{buildErr: jujutxn.ErrNoOperations}
+	composed := state.ComposeModelOperations(op1, op2)
+	_, err := composed.Build(0)
+	c.Assert(err, gc.Equals, jujutxn.ErrNoOperations)
+}
+
+func (s *ModelOperationSuite) TestComposeModelOperationsCommitTogether(c *gc.C) {
+	m0 := s.Factory.MakeMachine(c, nil)
+	m1 := s.Factory.MakeMachine(c, nil)
+	op1 := newAnnotateOperation(m0, nil)
+	op2 := newAnnotateOperation(m1, nil)
+
+	err := s.State.ApplyOperation(state.ComposeModelOperations(op1, op2))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, m := range []*state.Machine{m0, m1} {
+		value, err := s.State.Annotation(m, "composed")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(value, gc.Equals, "yes")
+	}
+	c.Assert(op1.succeeded, jc.IsTrue)
+	c.Assert(op2.succeeded, jc.IsTrue)
+}
+
+func (s *ModelOperationSuite) TestComposeModelOperationsRollBackTogether(c *gc.C) {
+	m0 := s.Factory.MakeMachine(c, nil)
+	m1 := s.Factory.MakeMachine(c, nil)
+	op1 := newAnnotateOperation(m0, nil)
+	// The second operation asserts something that is not true, so
+	// neither operation's changes may be applied.
+	op2 := newAnnotateOperation(m1, &txn.Op{
+		C:      "machines",
+		Id:     m1.Id(),
+		Assert: bson.D{{"life", state.Dead}},
+	})
+
+	err := s.State.ApplyOperation(state.ComposeModelOperations(op1, op2))
+	c.Assert(err, gc.ErrorMatches, "done: done: .*")
+	for _, m := range []*state.Machine{m0, m1} {
+		value, err := s.State.Annotation(m, "composed")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(value, gc.Equals, "")
+	}
+	c.Assert(op1.succeeded, jc.IsFalse)
+	c.Assert(op2.succeeded, jc.IsFalse)
+}
+
+// annotateOperation is a ModelOperation that inserts an annotation
+// document for a machine, recording in Done whether it was applied.
+type annotateOperation struct {
+	machine   *state.Machine
+	assert    *txn.Op
+	succeeded bool
+}
+
+func newAnnotateOperation(m *state.Machine, assert *txn.Op) *annotateOperation {
+	return &annotateOperation{machine: m, assert: assert}
+}
+
+func (op *annotateOperation) Build(attempt int) ([]txn.Op, error) {
+	ops := []txn.Op{{
+		C:      "annotations",
+		Id:     "m#" + op.machine.Id(),
+		Assert: txn.DocMissing,
+		Insert: bson.D{
+			{"globalkey", "m#" + op.machine.Id()},
+			{"tag", op.machine.Tag().String()},
+			{"annotations", bson.M{"composed": "yes"}},
+		},
+	}}
+	if op.assert != nil {
+		ops = append(ops, *op.assert)
+	}
+	return ops, nil
+}
+
+func (op *annotateOperation) Done(err error) error {
+	if err != nil {
+		return errors.Annotate(err, "done")
+	}
+	op.succeeded = true
+	return nil
+}
+
+type testModelOperation struct {
+	buildErr error
+	attempts int
+	done     bool
+}
+
+func (op *testModelOperation) Build(attempt int) ([]txn.Op, error) {
+	op.attempts++
+	return nil, op.buildErr
+}
+
+func (op *testModelOperation) Done(err error) error {
+	op.done = true
+	if err == nil {
+		return nil
+	}
+	return errors.Annotate(err, "done")
+}
@@ -123,7 +123,7 @@ func (st *State) modelUser(modelUUID string, user names.UserTag) (userAccessDoc,
 	return modelUser, nil
 }
 
-func createModelUserOps(modelUUID string, user, createdBy names.UserTag, displayName string, dateCreated time.Time, access permission.Access) []txn.Op {
+func createModelUserOps(modelUUID string, user, createdBy names.UserTag, displayName string, dateCreated time.Time, access permission.Access, expires *time.Time) []txn.Op {
 	creatorname := createdBy.Id()
 	doc := &userAccessDoc{
 		ID:          userAccessID(user),
@@ -132,6 +132,7 @@ func createModelUserOps(modelUUID string, user, createdBy names.UserTag, display
 		DisplayName: displayName,
 		CreatedBy:   creatorname,
 		DateCreated: dateCreated,
+		Expires:     utcTimePtr(expires),
 	}
 
 	ops := []txn.Op{
@@ -157,10 +158,25 @@ func removeModelUserOps(modelUUID string, user names.UserTag) []txn.Op {
 		}}
 }
 
+// removeModelUserAndAccessOps returns the operations that remove the
+// user from the model, along with any access they have been granted on
+// the model's applications.
+func removeModelUserAndAccessOps(st *State, modelUUID string, user names.UserTag) ([]txn.Op, error) {
+	ops := removeModelUserOps(modelUUID, user)
+	accessOps, err := removeUserApplicationAccessOps(st, modelUUID, user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, accessOps...), nil
+}
+
 // removeModelUser removes a user from the database.
 func (st *State) removeModelUser(user names.UserTag) error {
-	ops := removeModelUserOps(st.ModelUUID(), user)
-	err := st.runTransaction(ops)
+	ops, err := removeModelUserAndAccessOps(st, st.ModelUUID(), user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = st.runTransaction(ops)
 	if err == txn.ErrAborted {
 		err = errors.NewNotFound(nil, fmt.Sprintf("model user %q does not exist", user.Id()))
 	}
@@ -170,6 +186,75 @@ func (st *State) removeModelUser(user names.UserTag) error {
 	return nil
 }
 
+// SetModelUserExpiry sets when the user's access to the model lapses.
+// A nil expiry makes the access permanent.
+func (st *State) SetModelUserExpiry(user names.UserTag, expires *time.Time) error {
+	update := bson.D{{"$unset", bson.D{{"expires", 1}}}}
+	if expires != nil {
+		update = bson.D{{"$set", bson.D{{"expires", expires.UTC()}}}}
+	}
+	ops := []txn.Op{{
+		C:      modelUsersC,
+		Id:     userAccessID(user),
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("model user %q", user.Id())
+	}
+	return errors.Trace(err)
+}
+
+// RemoveExpiredModelUsers removes the access of every model user, in
+// any model, whose access has expired.
+func (st *State) RemoveExpiredModelUsers() error {
+	// A raw collection is required to support queries across
+	// multiple models.
+	modelUsers, closer := st.getRawCollection(modelUsersC)
+	defer closer()
+
+	now := st.clock.Now().UTC()
+	expired := bson.D{{"expires", bson.D{{"$lte", now}}}}
+	var docs []userAccessDoc
+	if err := modelUsers.Find(expired).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		user := names.NewUserTag(doc.UserName)
+		removeOps, err := removeModelUserAndAccessOps(st, doc.ObjectUUID, user)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Only remove the access if it is still expired.
+		ops := append([]txn.Op{{
+			C:      modelUsersC,
+			Id:     userAccessID(user),
+			Assert: expired,
+		}}, removeOps...)
+		err = st.runTransactionFor(doc.ObjectUUID, ops)
+		if err == txn.ErrAborted {
+			// The access has been changed or removed since
+			// we looked; leave it alone.
+			continue
+		}
+		if err != nil {
+			return errors.Annotatef(err, "removing expired access for %q to model %q", user.Id(), doc.ObjectUUID)
+		}
+		logger.Infof("access for %q to model %q has expired", user.Id(), doc.ObjectUUID)
+	}
+	return nil
+}
+
+// utcTimePtr returns a copy of t in UTC, or nil if t is nil.
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
 // UserModel contains information about an model that a
 // user has access to.
 type UserModel struct {
@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -303,6 +304,84 @@ func (s *ModelUserSuite) TestRemoveModelUserFails(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ModelUserSuite) TestAddModelUserWithExpiry(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	expires := s.Clock.Now().Add(time.Hour)
+	_, err := s.State.AddModelUser(
+		s.State.ModelUUID(),
+		state.UserAccessSpec{
+			User:      user.UserTag(),
+			CreatedBy: s.Owner,
+			Access:    permission.ReadAccess,
+			Expires:   &expires,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := s.State.UserAccess(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Expires, gc.NotNil)
+	c.Assert(modelUser.Expires.Equal(expires), jc.IsTrue)
+
+	err = s.State.SetModelUserExpiry(user.UserTag(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.UserAccess(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Expires, gc.IsNil)
+}
+
+func (s *ModelUserSuite) TestSetModelUserExpiryNotFound(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	expires := s.Clock.Now().Add(time.Hour)
+	err := s.State.SetModelUserExpiry(user.UserTag(), &expires)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelUserSuite) TestRemoveExpiredModelUsers(c *gc.C) {
+	expiring := s.Factory.MakeUser(c, &factory.UserParams{Name: "expiring"})
+	later := s.Factory.MakeUser(c, &factory.UserParams{Name: "later"})
+	permanent := s.Factory.MakeUser(c, &factory.UserParams{Name: "permanent"})
+	now := s.Clock.Now()
+	soon, notYet := now.Add(time.Minute), now.Add(time.Hour)
+	err := s.State.SetModelUserExpiry(expiring.UserTag(), &soon)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelUserExpiry(later.UserTag(), &notYet)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveExpiredModelUsers()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(expiring.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(2 * time.Minute)
+	err = s.State.RemoveExpiredModelUsers()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(expiring.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.UserAccess(later.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(permanent.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelUserSuite) TestRemoveExpiredModelUsersRemovesApplicationAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "expiring", Access: permission.ReadAccess})
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SetApplicationAccess(user.UserTag(), app.Name(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	expires := s.Clock.Now().Add(time.Minute)
+	err = s.State.SetModelUserExpiry(user.UserTag(), &expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(2 * time.Minute)
+	err = s.State.RemoveExpiredModelUsers()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	access, err := s.State.ApplicationAccess(user.UserTag(), app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
 func (s *ModelUserSuite) TestUpdateLastConnection(c *gc.C) {
 	now := s.State.NowToTheSecond()
 	createdBy := s.Factory.MakeUser(c, &factory.UserParams{Name: "createdby"})
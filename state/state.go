@@ -2132,6 +2132,21 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the audit entries recorded in the database that
+// match the filter, newest first.
+func (st *State) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	collection, closeCollection := st.getCollection(auditingC)
+	defer closeCollection()
+
+	iterDocs := func(_ string, query bson.D) stateaudit.Iterator {
+		// Entries get generated ObjectIds when inserted, which
+		// sort in the order the entries were recorded.
+		return collection.Find(query).Sort("-_id").Iter()
+	}
+	entries, err := stateaudit.GetAuditEntriesFn(auditingC, iterDocs)(filter)
+	return entries, errors.Trace(err)
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
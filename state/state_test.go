@@ -2821,6 +2821,67 @@ func (s *StateSuite) TestWatchForModelConfigControllerChanges(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *StateSuite) TestWatchModelConfig(c *gc.C) {
+	w := s.State.WatchModelConfig()
+	defer statetesting.AssertStop(c, w)
+
+	nextChanges := func() []state.ItemChange {
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			return changes
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for model config changes")
+		}
+		panic("unreachable")
+	}
+	assertNoChange := func() {
+		s.State.StartSync()
+		select {
+		case changes := <-w.Changes():
+			c.Fatalf("unexpected changes: %v", changes)
+		case <-time.After(testing.ShortWait):
+		}
+	}
+
+	// The initial event reports every key as added.
+	initial := nextChanges()
+	c.Assert(initial, gc.Not(gc.HasLen), 0)
+	for _, change := range initial {
+		c.Assert(change.Type, gc.Equals, state.ItemAdded)
+	}
+	assertNoChange()
+
+	err := s.State.UpdateModelConfig(map[string]interface{}{"extra-info": "foo"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	c.Assert(nextChanges(), jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemAdded, Key: "extra-info", NewValue: "foo"},
+	})
+	assertNoChange()
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"extra-info": "bar"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	c.Assert(nextChanges(), jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemModified, Key: "extra-info", OldValue: "foo", NewValue: "bar"},
+	})
+	assertNoChange()
+
+	// Setting a key to its current value does not produce an event.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"extra-info": "bar"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoChange()
+
+	err = s.State.UpdateModelConfig(nil, []string{"extra-info"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	c.Assert(nextChanges(), jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemDeleted, Key: "extra-info", OldValue: "bar"},
+	})
+	assertNoChange()
+}
+
 func (s *StateSuite) TestAddAndGetEquivalence(c *gc.C) {
 	// The equivalence tested here isn't necessarily correct, and
 	// comparing private details is discouraged in the project.
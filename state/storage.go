@@ -243,6 +243,54 @@ func (st *State) AllStorageInstances() (storageInstances []StorageInstance, err
 	return
 }
 
+// PersistentStorageInstances lists the storage instances in the model
+// whose storage is not bound to a machine, and so would outlive the
+// machines in the model. Storage from machine-scoped pools, such as
+// rootfs, tmpfs and loop, is not included.
+func (st *State) PersistentStorageInstances() ([]StorageInstance, error) {
+	all, err := st.AllStorageInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var persistent []StorageInstance
+	for _, s := range all {
+		detachable, err := isDetachableStorageInstance(st, s)
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking storage %q", s.StorageTag().Id())
+		}
+		if detachable {
+			persistent = append(persistent, s)
+		}
+	}
+	return persistent, nil
+}
+
+// isDetachableStorageInstance reports whether or not the volume or
+// filesystem backing the storage instance can be detached from its
+// machine. Storage that has no volume or filesystem yet has nothing
+// provisioned, and is not detachable.
+func isDetachableStorageInstance(st *State, s StorageInstance) (bool, error) {
+	switch s.Kind() {
+	case StorageKindBlock:
+		v, err := st.StorageInstanceVolume(s.StorageTag())
+		if errors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		return isDetachableVolumeTag(st, v.VolumeTag())
+	case StorageKindFilesystem:
+		f, err := st.StorageInstanceFilesystem(s.StorageTag())
+		if errors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		return isDetachableFilesystemTag(st, f.FilesystemTag())
+	}
+	return false, errors.Errorf("unknown storage kind %v", s.Kind())
+}
+
 // DestroyStorageInstance ensures that the storage instance and all its
 // attachments will be removed at some point; if the storage instance has
 // no attachments, it will be removed immediately.
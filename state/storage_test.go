@@ -366,6 +366,29 @@ type StorageStateSuite struct {
 
 var _ = gc.Suite(&StorageStateSuite{})
 
+func (s *StorageStateSuite) TestPersistentStorageInstances(c *gc.C) {
+	app := s.setupMixedScopeStorageApplication(c, "block")
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is provisioned until the unit is assigned to a machine.
+	persistent, err := s.State.PersistentStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(persistent, gc.HasLen, 0)
+
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 4)
+	persistent, err = s.State.PersistentStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(persistent, gc.HasLen, 2)
+	for _, s := range persistent {
+		c.Check(s.StorageName(), gc.Equals, "multi1to10")
+	}
+}
+
 func (s *StorageStateSuite) TestAddServiceStorageConstraintsDefault(c *gc.C) {
 	ch := s.AddTestingCharm(c, "storage-block")
 	storageBlock, err := s.State.AddApplication(state.AddApplicationArgs{Name: "storage-block", Charm: ch})
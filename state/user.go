@@ -147,7 +147,11 @@ func (st *State) RemoveUser(tag names.UserTag) error {
 			Assert: txn.DocExists,
 			Update: bson.M{"$set": bson.M{"deleted": true}},
 		}}
-		return ops, nil
+		groupOps, err := st.removeUserGroupMembershipOps(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, groupOps...), nil
 	}
 	return st.run(buildTxn)
 }
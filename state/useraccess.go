@@ -22,6 +22,10 @@ type userAccessDoc struct {
 	DisplayName string    `bson:"displayname"`
 	CreatedBy   string    `bson:"createdby"`
 	DateCreated time.Time `bson:"datecreated"`
+
+	// Expires, if set, is when the access lapses. It is only
+	// used for model users.
+	Expires *time.Time `bson:"expires,omitempty"`
 }
 
 // UserAccessSpec defines the attributes that can be set when adding a new
@@ -31,6 +35,10 @@ type UserAccessSpec struct {
 	CreatedBy   names.UserTag
 	DisplayName string
 	Access      permission.Access
+
+	// Expires, if set, is when model access is automatically
+	// revoked. It is not supported for controller access.
+	Expires *time.Time
 }
 
 // userAccessTarget defines the target of a user access granting.
@@ -90,9 +98,13 @@ func (st *State) addUserAccess(spec UserAccessSpec, target userAccessTarget) (pe
 			spec.CreatedBy,
 			spec.DisplayName,
 			st.NowToTheSecond(),
-			spec.Access)
+			spec.Access,
+			spec.Expires)
 		targetTag = names.NewModelTag(target.uuid)
 	case controllerGlobalKey:
+		if spec.Expires != nil {
+			return permission.UserAccess{}, errors.NotSupportedf("expiring controller access")
+		}
 		ops = createControllerUserOps(
 			st.ControllerUUID(),
 			spec.User,
@@ -150,6 +162,7 @@ func newUserAccess(perm *userPermission, userDoc userAccessDoc, object names.Tag
 		DateCreated: userDoc.DateCreated.UTC(),
 		DisplayName: userDoc.DisplayName,
 		UserName:    userDoc.UserName,
+		Expires:     utcTimePtr(userDoc.Expires),
 	}
 }
 
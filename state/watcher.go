@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -69,6 +70,13 @@ type StringsWatcher interface {
 	Changes() <-chan []string
 }
 
+// ItemChangesWatcher generates signals when settings change, returning
+// the changed items.
+type ItemChangesWatcher interface {
+	Watcher
+	Changes() <-chan []ItemChange
+}
+
 // RelationUnitsWatcher generates signals when units enter or leave
 // the scope of a RelationUnit, and changes to the settings of those
 // units known to have entered.
@@ -1366,6 +1374,13 @@ func (st *State) WatchForModelConfigChanges() NotifyWatcher {
 	return newEntityWatcher(st, settingsC, st.docID(modelGlobalKey))
 }
 
+// WatchModelConfig returns an ItemChangesWatcher reporting the individual
+// keys of the model config that are added, modified or deleted. The first
+// event reports every key in the current config as added.
+func (st *State) WatchModelConfig() ItemChangesWatcher {
+	return newSettingsItemChangesWatcher(st, settingsC, modelGlobalKey)
+}
+
 // WatchForUnitAssignment watches for new services that request units to be
 // assigned to machines.
 func (st *State) WatchForUnitAssignment() StringsWatcher {
@@ -1413,6 +1428,21 @@ func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
 	return newEntityWatcher(u.st, settingsC, u.st.docID(settingsKey)), nil
 }
 
+// WatchStatus returns a watcher observing changes to the workload and
+// agent status documents of the unit, both of which contribute to the
+// value reported by Unit.Status.
+func (u *Unit) WatchStatus() NotifyWatcher {
+	return newDocWatcher(u.st, []docKey{
+		{
+			statusesC,
+			u.st.docID(u.globalKey()),
+		}, {
+			statusesC,
+			u.st.docID(u.globalAgentKey()),
+		},
+	})
+}
+
 // WatchMeterStatus returns a watcher observing changes that affect the meter status
 // of a unit.
 func (u *Unit) WatchMeterStatus() NotifyWatcher {
@@ -1744,6 +1774,115 @@ func (w *machineAddressesWatcher) loop() error {
 	}
 }
 
+// settingsItemChangesWatcher notifies about changes to the individual
+// items of a settings document.
+type settingsItemChangesWatcher struct {
+	commonWatcher
+	collection string
+	key        string
+	out        chan []ItemChange
+}
+
+var _ ItemChangesWatcher = (*settingsItemChangesWatcher)(nil)
+
+func newSettingsItemChangesWatcher(backend modelBackend, collection, key string) ItemChangesWatcher {
+	w := &settingsItemChangesWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		collection:    collection,
+		key:           key,
+		out:           make(chan []ItemChange),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *settingsItemChangesWatcher) Changes() <-chan []ItemChange {
+	return w.out
+}
+
+func (w *settingsItemChangesWatcher) readSettings() (map[string]interface{}, error) {
+	settings, err := readSettings(w.backend, w.collection, w.key)
+	if errors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings.Map(), nil
+}
+
+func (w *settingsItemChangesWatcher) loop() error {
+	docID := w.backend.docID(w.key)
+	coll, closer := w.db.GetCollection(w.collection)
+	revno, err := getTxnRevno(coll, docID)
+	closer()
+	if err != nil {
+		return err
+	}
+	ch := make(chan watcher.Change)
+	w.watcher.Watch(w.collection, docID, revno, ch)
+	defer w.watcher.Unwatch(w.collection, docID, ch)
+
+	// delivered holds the settings as last seen by the receiver;
+	// pending changes are always computed against it, so that
+	// several updates between reads collapse into a single event.
+	delivered := map[string]interface{}{}
+	current, err := w.readSettings()
+	if err != nil {
+		return err
+	}
+	changes := diffSettings(delivered, current)
+	out := w.out
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-ch:
+			if current, err = w.readSettings(); err != nil {
+				return err
+			}
+			changes = diffSettings(delivered, current)
+			if len(changes) > 0 {
+				out = w.out
+			} else {
+				out = nil
+			}
+		case out <- changes:
+			delivered = current
+			changes = nil
+			out = nil
+		}
+	}
+}
+
+// diffSettings returns the changes, sorted by key, needed to turn the
+// old settings into the new ones.
+func diffSettings(old, new map[string]interface{}) []ItemChange {
+	var changes []ItemChange
+	for key := range cacheKeys(old, new) {
+		oldValue, inOld := old[key]
+		newValue, inNew := new[key]
+		switch {
+		case inOld && inNew:
+			if !reflect.DeepEqual(oldValue, newValue) {
+				changes = append(changes, ItemChange{ItemModified, key, oldValue, newValue})
+			}
+		case inNew:
+			changes = append(changes, ItemChange{ItemAdded, key, nil, newValue})
+		default:
+			changes = append(changes, ItemChange{ItemDeleted, key, oldValue, nil})
+		}
+	}
+	sort.Sort(itemChangeSlice(changes))
+	return changes
+}
+
 // WatchCleanups starts and returns a CleanupWatcher.
 func (st *State) WatchCleanups() NotifyWatcher {
 	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
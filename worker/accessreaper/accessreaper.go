@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package accessreaper
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	jworker "github.com/juju/juju/worker"
+)
+
+// ExpiredAccessRemover defines the interface for types capable of
+// removing model access that has expired.
+type ExpiredAccessRemover interface {
+	RemoveExpiredModelUsers() error
+}
+
+// New returns a worker which periodically revokes model access
+// whose expiry has passed.
+func New(remover ExpiredAccessRemover, interval time.Duration, clock clock.Clock) worker.Worker {
+	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		for {
+			select {
+			case <-clock.After(interval):
+				err := remover.RemoveExpiredModelUsers()
+				if err != nil {
+					return errors.Annotate(err, "removing expired model access")
+				}
+			case <-stopCh:
+				return nil
+			}
+		}
+	})
+}
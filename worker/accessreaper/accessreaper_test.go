@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package accessreaper_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/accessreaper"
+)
+
+type AccessReaperSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AccessReaperSuite{})
+
+func (s *AccessReaperSuite) TestRemovesExpiredAccess(c *gc.C) {
+	remover := newFakeRemover(nil)
+	testClock := testing.NewClock(time.Now())
+	interval := time.Minute
+	w := accessreaper.New(remover, interval, testClock)
+	defer w.Kill()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-testClock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for worker to wait")
+		}
+		testClock.Advance(interval)
+		select {
+		case <-remover.called:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for expired access to be removed")
+		}
+	}
+}
+
+func (s *AccessReaperSuite) TestRemoveError(c *gc.C) {
+	remover := newFakeRemover(errors.New("boom"))
+	testClock := testing.NewClock(time.Now())
+	w := accessreaper.New(remover, time.Minute, testClock)
+	defer w.Kill()
+
+	select {
+	case <-testClock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+	testClock.Advance(time.Minute)
+	<-remover.called
+	c.Assert(w.Wait(), gc.ErrorMatches, "removing expired model access: boom")
+}
+
+func (s *AccessReaperSuite) TestStops(c *gc.C) {
+	w := accessreaper.New(newFakeRemover(nil), time.Minute, clock.WallClock)
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+}
+
+func newFakeRemover(err error) *fakeRemover {
+	return &fakeRemover{
+		called: make(chan struct{}, 1),
+		err:    err,
+	}
+}
+
+type fakeRemover struct {
+	called chan struct{}
+	err    error
+}
+
+// RemoveExpiredModelUsers implements accessreaper.ExpiredAccessRemover.
+func (r *fakeRemover) RemoveExpiredModelUsers() error {
+	r.called <- struct{}{}
+	return r.err
+}
@@ -16,13 +16,20 @@ import (
 var logger = loggo.GetLogger("juju.worker.environ")
 
 // ConfigObserver exposes a model configuration and a watch constructor
-// that allows clients to be informed of changes to the configuration.
+// that allows clients to be informed of changes to the configuration,
+// along with the model's cloud credential and a watch constructor for
+// changes to it.
 type ConfigObserver interface {
 	environs.EnvironConfigGetter
 	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelCredential() (names.CloudCredentialTag, bool, error)
 	WatchCredential(tag names.CloudCredentialTag) (watcher.NotifyWatcher, error)
 }
 
+// ErrCredentialChanged is returned by a Tracker when the model's cloud
+// credential has changed, and the environ must be opened again.
+var ErrCredentialChanged = errors.New("model cloud credential changed")
+
 // Config describes the dependencies of a Tracker.
 //
 // It's arguable that it should be called TrackerConfig, because of the heavy
@@ -94,11 +101,23 @@ func (t *Tracker) loop() error {
 	if err := t.catacomb.Add(environWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	credentialChanges, err := t.watchCredential()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	for {
 		logger.Debugf("waiting for environ watch notification")
 		select {
 		case <-t.catacomb.Dying():
 			return t.catacomb.ErrDying()
+		case _, ok := <-credentialChanges:
+			if !ok {
+				return errors.New("credential watch closed")
+			}
+			// The credential is only supplied when the environ is
+			// opened, so it must be opened again.
+			logger.Infof("model cloud credential changed, restarting")
+			return ErrCredentialChanged
 		case _, ok := <-environWatcher.Changes():
 			if !ok {
 				return errors.New("environ config watch closed")
@@ -115,6 +134,41 @@ func (t *Tracker) loop() error {
 	}
 }
 
+// watchCredential returns a channel that receives a value when the
+// model's cloud credential changes. If the model has no credential,
+// the returned channel is nil.
+func (t *Tracker) watchCredential() (watcher.NotifyChannel, error) {
+	tag, ok, err := t.config.Observer.ModelCredential()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching model cloud credential: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get model cloud credential")
+	}
+	if !ok {
+		return nil, nil
+	}
+	credentialWatcher, err := t.config.Observer.WatchCredential(tag)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot watch model cloud credential")
+	}
+	if err := t.catacomb.Add(credentialWatcher); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The watcher always sends an initial event, which reflects the
+	// credential the environ was just opened with; consume it so only
+	// subsequent changes cause a restart.
+	select {
+	case <-t.catacomb.Dying():
+		return nil, t.catacomb.ErrDying()
+	case _, ok := <-credentialWatcher.Changes():
+		if !ok {
+			return nil, errors.New("credential watch closed")
+		}
+	}
+	return credentialWatcher.Changes(), nil
+}
+
 // Kill is part of the worker.Worker interface.
 func (t *Tracker) Kill() {
 	t.catacomb.Kill(nil)
@@ -155,14 +155,14 @@ func (s *TrackerSuite) TestWatchCloses(c *gc.C) {
 		context.CloseModelConfigNotify()
 		err = workertest.CheckKilled(c, tracker)
 		c.Check(err, gc.ErrorMatches, "environ config watch closed")
-		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges")
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential")
 	})
 }
 
 func (s *TrackerSuite) TestWatchedModelConfigFails(c *gc.C) {
 	fix := &fixture{
 		observerErrs: []error{
-			nil, nil, nil, errors.New("blam ouch"),
+			nil, nil, nil, nil, errors.New("blam ouch"),
 		},
 	}
 	fix.Run(c, func(context *runContext) {
@@ -176,7 +176,7 @@ func (s *TrackerSuite) TestWatchedModelConfigFails(c *gc.C) {
 		context.SendModelConfigNotify()
 		err = workertest.CheckKilled(c, tracker)
 		c.Check(err, gc.ErrorMatches, "cannot read environ config: blam ouch")
-		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelConfig")
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential", "ModelConfig")
 	})
 }
 
@@ -197,7 +197,7 @@ func (s *TrackerSuite) TestWatchedModelConfigIncompatible(c *gc.C) {
 		context.SendModelConfigNotify()
 		err = workertest.CheckKilled(c, tracker)
 		c.Check(err, gc.ErrorMatches, "cannot update environ config: SetConfig is broken")
-		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelConfig")
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential", "ModelConfig")
 	})
 }
 
@@ -238,6 +238,120 @@ func (s *TrackerSuite) TestWatchedModelConfigUpdates(c *gc.C) {
 			}
 			break
 		}
-		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelConfig")
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential", "ModelConfig")
+	})
+}
+
+func (s *TrackerSuite) TestModelCredentialFails(c *gc.C) {
+	fix := &fixture{
+		observerErrs: []error{
+			nil, nil, nil, errors.New("no credential for you"),
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer:       context,
+			NewEnvironFunc: newMockEnviron,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot get model cloud credential: no credential for you")
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential")
+	})
+}
+
+func (s *TrackerSuite) TestModelCredentialNotSupported(c *gc.C) {
+	fix := &fixture{
+		observerErrs: []error{
+			nil, nil, nil, errors.NotSupportedf("ModelCredential"),
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer:       context,
+			NewEnvironFunc: newMockEnviron,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		workertest.CheckAlive(c, tracker)
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential")
+	})
+}
+
+func (s *TrackerSuite) TestWatchCredentialFails(c *gc.C) {
+	fix := &fixture{
+		credential: "dummy/fred/default",
+		observerErrs: []error{
+			nil, nil, nil, nil, errors.New("blind"),
+		},
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer:       context,
+			NewEnvironFunc: newMockEnviron,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "cannot watch model cloud credential: blind")
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential", "WatchCredential")
+	})
+}
+
+func (s *TrackerSuite) TestCredentialWatchCloses(c *gc.C) {
+	fix := &fixture{
+		credential: "dummy/fred/default",
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer:       context,
+			NewEnvironFunc: newMockEnviron,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.CloseCredentialNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.ErrorMatches, "credential watch closed")
+	})
+}
+
+func (s *TrackerSuite) TestCredentialInitialEventIgnored(c *gc.C) {
+	fix := &fixture{
+		credential: "dummy/fred/default",
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer:       context,
+			NewEnvironFunc: newMockEnviron,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		workertest.CheckAlive(c, tracker)
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential", "WatchCredential")
+	})
+}
+
+func (s *TrackerSuite) TestCredentialChanged(c *gc.C) {
+	fix := &fixture{
+		credential: "dummy/fred/default",
+	}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer:       context,
+			NewEnvironFunc: newMockEnviron,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		context.SendCredentialNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.Equals, environ.ErrCredentialChanged)
+		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelCredential", "WatchCredential")
 	})
 }
@@ -22,16 +22,23 @@ type fixture struct {
 	watcherErr    error
 	observerErrs  []error
 	cloud         environs.CloudSpec
+	credential    string
 	initialConfig map[string]interface{}
 }
 
 func (fix *fixture) Run(c *gc.C, test func(*runContext)) {
 	watcher := newNotifyWatcher(fix.watcherErr)
 	defer workertest.DirtyKill(c, watcher)
+	credWatcher := newNotifyWatcher(fix.watcherErr)
+	defer workertest.DirtyKill(c, credWatcher)
+	// Like the real credential watcher, send an initial event.
+	credWatcher.changes <- struct{}{}
 	context := &runContext{
-		cloud:   fix.cloud,
-		config:  newModelConfig(c, fix.initialConfig),
-		watcher: watcher,
+		cloud:       fix.cloud,
+		credential:  fix.credential,
+		config:      newModelConfig(c, fix.initialConfig),
+		watcher:     watcher,
+		credWatcher: credWatcher,
 	}
 	context.stub.SetErrors(fix.observerErrs...)
 	test(context)
@@ -41,6 +48,7 @@ type runContext struct {
 	mu          sync.Mutex
 	stub        testing.Stub
 	cloud       environs.CloudSpec
+	credential  string
 	config      map[string]interface{}
 	watcher     *notifyWatcher
 	credWatcher *notifyWatcher
@@ -103,19 +111,33 @@ func (context *runContext) WatchForModelConfigChanges() (watcher.NotifyWatcher,
 	return context.watcher, nil
 }
 
-// KillCredentialNotify kills the watcher returned from WatchCredentialChanges with
+// ModelCredential is part of the environ.ConfigObserver interface.
+func (context *runContext) ModelCredential() (names.CloudCredentialTag, bool, error) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.stub.AddCall("ModelCredential")
+	if err := context.stub.NextErr(); err != nil {
+		return names.CloudCredentialTag{}, false, err
+	}
+	if context.credential == "" {
+		return names.CloudCredentialTag{}, false, nil
+	}
+	return names.NewCloudCredentialTag(context.credential), true, nil
+}
+
+// KillCredentialNotify kills the watcher returned from WatchCredential with
 // the error configured in the enclosing fixture.
 func (context *runContext) KillCredentialNotify() {
 	context.credWatcher.Kill()
 }
 
-// SendCredentialNotify sends a value on the channel used by WatchCredentialChanges
+// SendCredentialNotify sends a value on the channel used by WatchCredential
 // results.
 func (context *runContext) SendCredentialNotify() {
 	context.credWatcher.changes <- struct{}{}
 }
 
-// CloseCredentialNotify closes the channel used by WatchCredentialChanges results.
+// CloseCredentialNotify closes the channel used by WatchCredential results.
 func (context *runContext) CloseCredentialNotify() {
 	close(context.credWatcher.changes)
 }
@@ -124,11 +146,11 @@ func (context *runContext) CloseCredentialNotify() {
 func (context *runContext) WatchCredential(cred names.CloudCredentialTag) (watcher.NotifyWatcher, error) {
 	context.mu.Lock()
 	defer context.mu.Unlock()
-	context.stub.AddCall("WatchCredential")
+	context.stub.AddCall("WatchCredential", cred)
 	if err := context.stub.NextErr(); err != nil {
 		return nil, err
 	}
-	return context.watcher, nil
+	return context.credWatcher, nil
 }
 
 func (context *runContext) CheckCallNames(c *gc.C, names ...string) {
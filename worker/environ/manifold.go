@@ -27,6 +27,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			config.APICallerName,
 		},
 		Output: manifoldOutput,
+		Filter: bounceCredentialChanged,
 		Start: func(context dependency.Context) (worker.Worker, error) {
 			var apiCaller base.APICaller
 			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
@@ -58,3 +59,13 @@ func manifoldOutput(in worker.Worker, out interface{}) error {
 	*outEnviron = inTracker.Environ()
 	return nil
 }
+
+// bounceCredentialChanged converts ErrCredentialChanged to
+// dependency.ErrBounce, so that the Tracker is restarted with the new
+// credential along with the workers that use its environ.
+func bounceCredentialChanged(err error) error {
+	if errors.Cause(err) == ErrCredentialChanged {
+		return dependency.ErrBounce
+	}
+	return err
+}
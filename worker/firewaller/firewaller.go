@@ -284,6 +284,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedPortRanges = change.exposedPortRanges
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -438,18 +439,23 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	exposedPortRanges, err := getExposedPortRanges(app)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:                fw,
+		application:       app,
+		exposed:           exposed,
+		exposedPortRanges: exposedPortRanges,
+		unitds:            make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedPortRanges)
 		},
 	})
 	if err != nil {
@@ -711,12 +717,26 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 
 			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
+			applicationd := unitd.applicationd
+			if applicationd.exposed && len(applicationd.exposedPortRanges) == 0 {
+				// If the unit is exposed, allow access from everywhere.
 				cidrs.Add("0.0.0.0/0")
 			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+				if applicationd.exposed {
+					// Only the port ranges of the exposed endpoints
+					// are accessible from everywhere.
+					for portRange := range portRanges {
+						for _, exposedRange := range intersectPortRanges(portRange, applicationd.exposedPortRanges) {
+							rule, err := network.NewIngressRule(exposedRange.Protocol, exposedRange.FromPort, exposedRange.ToPort, "0.0.0.0/0")
+							if err != nil {
+								return nil, errors.Trace(err)
+							}
+							want = append(want, rule)
+						}
+					}
+				}
+				// Add any ingress rules required by remote relations.
+				if err := fw.updateForRemoteRelationIngress(applicationd.application.Tag(), cidrs); err != nil {
 					return nil, errors.Trace(err)
 				}
 				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
@@ -994,10 +1014,12 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and exposed port
+// ranges for one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd      *applicationData
+	exposed           bool
+	exposedPortRanges []network.PortRange
 }
 
 // applicationData holds application details and watches exposure changes.
@@ -1006,11 +1028,15 @@ type applicationData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	// exposedPortRanges holds the port ranges of the application's
+	// exposed endpoints; when empty, all opened ports are exposed.
+	exposedPortRanges []network.PortRange
+	unitds            map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and exposed port
+// ranges for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedPortRanges []network.PortRange) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1039,13 +1065,18 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			portRangesChange, err := getExposedPortRanges(ad.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && samePortRanges(portRangesChange, exposedPortRanges) {
 				continue
 			}
 
 			exposed = change
+			exposedPortRanges = portRangesChange
 			select {
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, change, portRangesChange}:
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
 			}
@@ -1053,6 +1084,70 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 	}
 }
 
+// getExposedPortRanges returns the port ranges of the application's
+// exposed endpoints. Controllers that cannot report them do not record
+// exposed endpoints, so all opened ports are exposed.
+func getExposedPortRanges(app *firewaller.Application) ([]network.PortRange, error) {
+	portRanges, err := app.ExposedPortRanges()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	}
+	return portRanges, err
+}
+
+// samePortRanges reports whether the two slices hold the same port
+// ranges in the same order.
+func samePortRanges(a, b []network.PortRange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// intersectPortRanges returns the parts of portRange that lie within
+// any of the allowed port ranges. Allowed ranges may overlap, as
+// several exposed endpoints can share ports, so the parts are sorted
+// and overlapping or adjacent parts are merged into one range.
+func intersectPortRanges(portRange network.PortRange, allowed []network.PortRange) []network.PortRange {
+	var parts []network.PortRange
+	for _, allowedRange := range allowed {
+		if !strings.EqualFold(allowedRange.Protocol, portRange.Protocol) {
+			continue
+		}
+		from, to := portRange.FromPort, portRange.ToPort
+		if allowedRange.FromPort > from {
+			from = allowedRange.FromPort
+		}
+		if allowedRange.ToPort < to {
+			to = allowedRange.ToPort
+		}
+		if from <= to {
+			parts = append(parts, network.PortRange{
+				FromPort: from,
+				ToPort:   to,
+				Protocol: portRange.Protocol,
+			})
+		}
+	}
+	network.SortPortRanges(parts)
+	var result []network.PortRange
+	for _, part := range parts {
+		if n := len(result); n > 0 && part.FromPort <= result[n-1].ToPort+1 {
+			if part.ToPort > result[n-1].ToPort {
+				result[n-1].ToPort = part.ToPort
+			}
+			continue
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
 // Kill is part of the worker.Worker interface.
 func (ad *applicationData) Kill() {
 	ad.catacomb.Kill(nil)
@@ -490,6 +490,85 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeEndpoints(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPorts("tcp", 8000, 8100)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 9000)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the opened parts of the exposed port ranges are opened.
+	err = app.ExposeEndpoints(map[string][]network.PortRange{
+		"url": {
+			{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			{FromPort: 8080, ToPort: 8200, Protocol: "tcp"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8100, "0.0.0.0/0"),
+	})
+
+	// Exposing the whole application opens all the ports.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8000, 8100, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 9000, 9000, "0.0.0.0/0"),
+	})
+
+	// ClearExposed closes the ports again.
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposeEndpointsOverlappingRanges(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPorts("tcp", 8000, 8100)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ranges shared by several endpoints are opened once, and
+	// overlapping ranges are merged.
+	err = app.ExposeEndpoints(map[string][]network.PortRange{
+		"url": {
+			{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			{FromPort: 8000, ToPort: 8050, Protocol: "tcp"},
+		},
+		"monitoring-port": {
+			{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			{FromPort: 8040, ToPort: 8080, Protocol: "tcp"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8000, 8080, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -128,10 +128,14 @@ func (a *aggregator) doRequests(reqs []instanceInfoReq) error {
 	insts, err := a.config.Environ.Instances(ids)
 	for i, req := range reqs {
 		var reply instanceInfoReply
-		if err != nil && err != environs.ErrPartialInstances {
-			reply.err = err
-		} else {
+		switch err {
+		case nil, environs.ErrPartialInstances:
 			reply.info, reply.err = a.instInfo(req.instId, insts[i])
+		case environs.ErrNoInstances:
+			// None of the instances exist any more.
+			reply.info, reply.err = a.instInfo(req.instId, nil)
+		default:
+			reply.err = err
 		}
 		select {
 		// Per review http://reviews.vapour.ws/r/4885/ it's dumb to block
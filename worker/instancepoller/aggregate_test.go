@@ -344,7 +344,7 @@ func (s *aggregateSuite) TestInstancesErrors(c *gc.C) {
 	}
 
 	testGetter.newTestInstance("foo", "foobar", []string{"192.168.1.2"})
-	testGetter.err = environs.ErrNoInstances
+	testGetter.err = errors.New("boom")
 	aggregator, err := newAggregator(cfg)
 	c.Check(err, jc.ErrorIsNil)
 
@@ -356,7 +356,7 @@ func (s *aggregateSuite) TestInstancesErrors(c *gc.C) {
 	go func() {
 		defer wg.Done()
 		_, err = aggregator.instanceInfo("foo")
-		c.Assert(err, gc.Equals, environs.ErrNoInstances)
+		c.Assert(err, gc.Equals, testGetter.err)
 	}()
 
 	// Unwind to let our request through.
@@ -368,7 +368,39 @@ func (s *aggregateSuite) TestInstancesErrors(c *gc.C) {
 	// Kill the worker so we know there is no race checking the erroringTestGetter.
 	workertest.CleanKill(c, aggregator)
 
-	c.Assert(testGetter.err, gc.Equals, environs.ErrNoInstances)
+	c.Assert(testGetter.counter, gc.Equals, int32(1))
+}
+
+func (s *aggregateSuite) TestNoInstancesErrors(c *gc.C) {
+	testGetter := new(testInstanceGetter)
+	clock := jujutesting.NewClock(time.Now())
+	delay := time.Millisecond
+	cfg := aggregatorConfig{
+		Clock:   clock,
+		Delay:   delay,
+		Environ: testGetter,
+	}
+
+	testGetter.err = environs.ErrNoInstances
+	aggregator, err := newAggregator(cfg)
+	c.Check(err, jc.ErrorIsNil)
+
+	defer workertest.CleanKill(c, aggregator)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := aggregator.instanceInfo("foo")
+		c.Check(err, gc.ErrorMatches, "instance foo not found")
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}()
+
+	waitAlarms(c, clock, 1)
+	clock.Advance(delay)
+
+	wg.Wait()
+	workertest.CleanKill(c, aggregator)
 	c.Assert(testGetter.counter, gc.Equals, int32(1))
 }
 
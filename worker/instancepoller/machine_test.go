@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
@@ -81,6 +82,37 @@ func (s *machineSuite) TestSetsInstanceInfoDeadMachineInitially(c *gc.C) {
 	c.Assert(m.instStatusInfo, gc.Equals, "deleting")
 }
 
+func (s *machineSuite) TestSetsInstanceMissing(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			c.Check(id, gc.Equals, instance.Id("i1234"))
+			return instanceInfo{}, errors.NotFoundf("instance %v", id)
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		instStatus: status.Running,
+		addresses:  testAddrs,
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.instStatus, gc.Equals, status.Unknown)
+	c.Assert(m.instStatusInfo, gc.Equals, "instance missing")
+	// The last known addresses are left alone.
+	c.Assert(m.addresses, gc.DeepEquals, testAddrs)
+	c.Assert(m.setAddressCount, gc.Equals, 0)
+}
+
 func (s *machineSuite) TestShortPollIntervalWhenNoAddress(c *gc.C) {
 	s.testShortPoll(c, nil, "i1234", "running", status.Started)
 }
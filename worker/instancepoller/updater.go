@@ -20,6 +20,10 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.instancepoller")
 
+// instanceMissingMessage is the instance status message recorded for
+// machines whose instance the provider no longer knows about.
+const instanceMissingMessage = "instance missing"
+
 // ShortPoll and LongPoll hold the polling intervals for the instance
 // updater. When a machine has no address or is not started, it will be
 // polled at ShortPoll intervals until it does, exponentially backing off
@@ -248,6 +252,9 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 		return instanceInfo{}, errors.Annotate(err, "cannot get machine's instance id")
 	}
 	instInfo, err = context.instanceInfo(instId)
+	if errors.IsNotFound(err) {
+		return instanceMissing(m, instId)
+	}
 	if err != nil {
 		// TODO (anastasiamac 2016-02-01) This does not look like it needs to be removed now.
 		if params.IsCodeNotImplemented(err) {
@@ -292,6 +299,30 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 	return instInfo, nil
 }
 
+// instanceMissing records on the machine that the provider no longer
+// knows about its instance, so that status reports the missing
+// instance instead of the last known instance status.
+func instanceMissing(m machine, instId instance.Id) (instanceInfo, error) {
+	missing := instance.InstanceStatus{
+		Status:  status.Unknown,
+		Message: instanceMissingMessage,
+	}
+	instStat, err := m.InstanceStatus()
+	if err != nil {
+		logger.Warningf("cannot get current instance status for machine %v: %v", m.Id(), err)
+		return instanceInfo{status: missing}, nil
+	}
+	if status.Status(instStat.Status) == missing.Status && instStat.Info == missing.Message {
+		return instanceInfo{status: missing}, nil
+	}
+	logger.Warningf("machine %q instance %q not found by the provider", m.Id(), instId)
+	if err := m.SetInstanceStatus(missing.Status, missing.Message, nil); err != nil {
+		logger.Errorf("cannot set instance status on %q: %v", m, err)
+		return instanceInfo{}, err
+	}
+	return instanceInfo{status: missing}, nil
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(a0, a1 []network.Address) bool {
 	if len(a0) != len(a1) {
@@ -81,6 +81,10 @@ juju-statepool-report () {
   jujuMachineOrUnit statepool/ $@
 }
 
+juju-apirequests-report () {
+  jujuMachineOrUnit apirequests/ $@
+}
+
 juju-statetracker-report () {
   jujuMachineOrUnit debug/pprof/juju/state/tracker?debug=1 $@
 }
@@ -92,5 +96,6 @@ export -f juju-goroutines
 export -f juju-heap-profile
 export -f juju-engine-report
 export -f juju-statepool-report
+export -f juju-apirequests-report
 export -f juju-statetracker-report
 `
@@ -40,6 +40,7 @@ type Config struct {
 	SocketName         string
 	DepEngine          DepEngineReporter
 	StatePool          IntrospectionReporter
+	APIRequests        IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
 }
 
@@ -60,6 +61,7 @@ type socketListener struct {
 	listener           *net.UnixListener
 	depEngine          DepEngineReporter
 	statePool          IntrospectionReporter
+	apiRequests        IntrospectionReporter
 	prometheusGatherer prometheus.Gatherer
 	done               chan struct{}
 }
@@ -90,6 +92,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		listener:           l,
 		depEngine:          config.DepEngine,
 		statePool:          config.StatePool,
+		apiRequests:        config.APIRequests,
 		prometheusGatherer: config.PrometheusGatherer,
 		done:               make(chan struct{}),
 	}
@@ -104,6 +107,7 @@ func (w *socketListener) serve() {
 		ReportSources{
 			DependencyEngine:   w.depEngine,
 			StatePool:          w.statePool,
+			APIRequests:        w.apiRequests,
 			PrometheusGatherer: w.prometheusGatherer,
 		}, mux.Handle)
 
@@ -139,6 +143,7 @@ func (w *socketListener) Wait() error {
 type ReportSources struct {
 	DependencyEngine   DepEngineReporter
 	StatePool          IntrospectionReporter
+	APIRequests        IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
 }
 
@@ -159,6 +164,10 @@ func RegisterHTTPHandlers(
 		name:     "State Pool Report",
 		reporter: sources.StatePool,
 	})
+	handle("/apirequests/", introspectionReporterHandler{
+		name:     "API Requests Report",
+		reporter: sources.APIRequests,
+	})
 	handle("/metrics", promhttp.HandlerFor(sources.PrometheusGatherer, promhttp.HandlerOpts{}))
 }
 
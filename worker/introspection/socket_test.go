@@ -124,6 +124,12 @@ func (s *introspectionSuite) TestMissingStatePoolReporter(c *gc.C) {
 	matches(c, buf, "State Pool Report: missing reporter")
 }
 
+func (s *introspectionSuite) TestMissingAPIRequestsReporter(c *gc.C) {
+	buf := s.call(c, "/apirequests/")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "API Requests Report: missing reporter")
+}
+
 func (s *introspectionSuite) TestStateTrackerReporter(c *gc.C) {
 	buf := s.call(c, "/debug/pprof/juju/state/tracker")
 	matches(c, buf, "200 OK")
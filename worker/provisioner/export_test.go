@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"time"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
@@ -32,7 +34,12 @@ var (
 	ResolvConf               = &resolvConf
 	RetryStrategyDelay       = &retryStrategyDelay
 	RetryStrategyCount       = &retryStrategyCount
+	RetryStrategyMaxDelay    = &retryStrategyMaxDelay
 	GetObservedNetworkConfig = &getObservedNetworkConfig
 )
 
 var ClassifyMachine = classifyMachine
+
+func RetryDelay(s RetryStrategy, attempt int) time.Duration {
+	return s.delay(attempt)
+}
@@ -31,6 +31,10 @@ var _ Provisioner = (*containerProvisioner)(nil)
 var (
 	retryStrategyDelay = 10 * time.Second
 	retryStrategyCount = 3
+
+	// retryStrategyMaxDelay caps the delay between successive
+	// attempts to start an instance.
+	retryStrategyMaxDelay = 5 * time.Minute
 )
 
 // Provisioner represents a running provisioner worker.
@@ -85,6 +89,20 @@ func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	}
 }
 
+// delay returns the time to wait after the given failed attempt,
+// counting from zero, before trying again. The delay doubles with
+// each attempt, up to retryStrategyMaxDelay.
+func (s RetryStrategy) delay(attempt int) time.Duration {
+	delay := s.retryDelay
+	for i := 0; i < attempt && delay < retryStrategyMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryStrategyMaxDelay {
+		delay = retryStrategyMaxDelay
+	}
+	return delay
+}
+
 // configObserver is implemented so that tests can see
 // when the environment configuration changes.
 type configObserver struct {
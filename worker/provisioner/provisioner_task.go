@@ -722,7 +722,8 @@ func (task *provisionerTask) startMachine(
 	if err := machine.SetInstanceStatus(status.Provisioning, "starting", nil); err != nil {
 		logger.Errorf("%v", err)
 	}
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; attemptsLeft-- {
+	retryCount := task.retryStartInstanceStrategy.retryCount
+	for attemptsLeft := retryCount; attemptsLeft >= 0; attemptsLeft-- {
 		attemptResult, err := task.broker.StartInstance(startInstanceParams)
 		if err == nil {
 			result = attemptResult
@@ -734,8 +735,9 @@ func (task *provisionerTask) startMachine(
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		}
 
+		retryDelay := task.retryStartInstanceStrategy.delay(retryCount - attemptsLeft)
 		retryMsg := fmt.Sprintf("failed to start instance (%s), retrying in %v (%d more attempts)",
-			err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft)
+			err.Error(), retryDelay, attemptsLeft)
 		logger.Warningf(retryMsg)
 		if err2 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err2 != nil {
 			logger.Errorf("%v", err2)
@@ -744,7 +746,7 @@ func (task *provisionerTask) startMachine(
 		select {
 		case <-task.catacomb.Dying():
 			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+		case <-time.After(retryDelay):
 		}
 	}
 
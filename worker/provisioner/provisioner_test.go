@@ -710,6 +710,33 @@ func (s *ProvisionerSuite) TestProvisioningDoesNotOccurForKVM(c *gc.C) {
 	s.waitForRemovalMark(c, m)
 }
 
+type RetryStrategySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RetryStrategySuite{})
+
+func (s *RetryStrategySuite) TestDelayBacksOff(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyMaxDelay, time.Minute)
+	strategy := provisioner.NewRetryStrategy(10*time.Second, 5)
+	var delays []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		delays = append(delays, provisioner.RetryDelay(strategy, attempt))
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		time.Minute,
+		time.Minute,
+	})
+}
+
+func (s *RetryStrategySuite) TestZeroDelay(c *gc.C) {
+	strategy := provisioner.NewRetryStrategy(0, 3)
+	c.Assert(provisioner.RetryDelay(strategy, 2), gc.Equals, time.Duration(0))
+}
+
 type MachineClassifySuite struct {
 }
 